@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// Client extends the APISIX API client with the Admin API endpoints
+// which are not covered by the api_client package.
+type Client struct {
+	*api_client.ApiClient
+
+	// pluginSchemas is shared by the copies of the client.
+	pluginSchemas *PluginSchemas
+}
+
+// NewClient wraps the configured APISIX API client.
+func NewClient(apiClient *api_client.ApiClient) *Client {
+	return &Client{
+		ApiClient:     apiClient,
+		pluginSchemas: newPluginSchemas(),
+	}
+}
+
+// WithContext returns a copy of the client which sends the requests within the context,
+// so the client calls are canceled once the deadline of the Terraform operation is exceeded.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	httpClient := *c.HTTPClient
+	httpClient.Transport = &contextTransport{
+		ctx:    ctx,
+		nested: c.HTTPClient.Transport,
+	}
+
+	apiClient := *c.ApiClient
+	apiClient.HTTPClient = &httpClient
+
+	client := NewClient(&apiClient)
+	client.pluginSchemas = c.pluginSchemas
+
+	return client
+}
+
+// contextTransport sends the requests within the context.
+type contextTransport struct {
+	ctx    context.Context
+	nested http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	nested := t.nested
+	if nested == nil {
+		nested = http.DefaultTransport
+	}
+
+	return nested.RoundTrip(req.WithContext(t.ctx))
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	req.Header.Set("X-API-KEY", c.APIKey)
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// if status code >= 400
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, &StatusError{StatusCode: res.StatusCode, Body: body}
+	}
+
+	return body, err
+}
@@ -0,0 +1,176 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// unhealthyEndpointCooldown is the time an endpoint is skipped after a connection error.
+const unhealthyEndpointCooldown = 30 * time.Second
+
+// EndpointsTransport spreads the requests over several Admin API endpoints in the round-robin
+// order and fails over to the next endpoint when the current one returns a connection error.
+// The requests are built for the first endpoint and rewritten to the chosen one.
+type EndpointsTransport struct {
+	Nested http.RoundTripper
+
+	endpoints      []*url.URL
+	mutex          sync.Mutex
+	next           int
+	unhealthyUntil []time.Time
+}
+
+// NewEndpointsTransport wraps the transport with the failover between the endpoints.
+func NewEndpointsTransport(endpoints []string, nested http.RoundTripper) (*EndpointsTransport, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("at least one endpoint must be provided")
+	}
+
+	t := &EndpointsTransport{
+		Nested:         nested,
+		unhealthyUntil: make([]time.Time, len(endpoints)),
+	}
+
+	for _, endpoint := range endpoints {
+		endpointURL, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+		if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %q, expected the URL such as http://127.0.0.1:9180", endpoint)
+		}
+		t.endpoints = append(t.endpoints, endpointURL)
+	}
+
+	return t, nil
+}
+
+// Endpoint returns the endpoint the requests are built for.
+func (t *EndpointsTransport) Endpoint() string {
+	return t.endpoints[0].String()
+}
+
+func (t *EndpointsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var lastErr error
+	for _, index := range t.order() {
+		endpoint := t.endpoints[index]
+
+		endpointReq, err := t.rewrite(req, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, "Sending the APISIX Admin API request", map[string]any{
+			"endpoint": endpoint.String(),
+			"method":   req.Method,
+			"path":     endpointReq.URL.Path,
+		})
+
+		res, err := t.Nested.RoundTrip(endpointReq)
+		if err == nil {
+			t.markHealthy(index)
+			return res, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || !isConnectionError(err) {
+			return nil, err
+		}
+
+		t.markUnhealthy(index)
+		tflog.Warn(ctx, "APISIX Admin API endpoint is unavailable", map[string]any{
+			"endpoint": endpoint.String(),
+			"error":    err.Error(),
+		})
+
+		// The request may have reached the endpoint unless the connection failed,
+		// so the non-idempotent requests are not sent to the other endpoints.
+		if !isIdempotent(req.Method) && !isDialError(err) {
+			return nil, err
+		}
+
+		// The body can't be sent again
+		if req.Body != nil && req.GetBody == nil {
+			return nil, err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// order returns the indexes of the endpoints to try, starting from the next healthy one
+// in the round-robin order. The unhealthy endpoints are tried last.
+func (t *EndpointsTransport) order() []int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	start := t.next
+	t.next = (t.next + 1) % len(t.endpoints)
+
+	healthy := make([]int, 0, len(t.endpoints))
+	unhealthy := make([]int, 0)
+	for i := range t.endpoints {
+		index := (start + i) % len(t.endpoints)
+		if now.Before(t.unhealthyUntil[index]) {
+			unhealthy = append(unhealthy, index)
+		} else {
+			healthy = append(healthy, index)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (t *EndpointsTransport) markHealthy(index int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.unhealthyUntil[index] = time.Time{}
+}
+
+func (t *EndpointsTransport) markUnhealthy(index int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.unhealthyUntil[index] = time.Now().Add(unhealthyEndpointCooldown)
+}
+
+// rewrite returns the copy of the request sent to the endpoint.
+func (t *EndpointsTransport) rewrite(req *http.Request, endpoint *url.URL) (*http.Request, error) {
+	endpointReq := req.Clone(req.Context())
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		endpointReq.Body = body
+	}
+
+	primary := t.endpoints[0]
+	endpointReq.URL.Scheme = endpoint.Scheme
+	endpointReq.URL.Host = endpoint.Host
+	endpointReq.URL.Path = endpoint.Path + strings.TrimPrefix(req.URL.Path, primary.Path)
+	endpointReq.Host = ""
+
+	return endpointReq, nil
+}
+
+// isConnectionError reports whether the endpoint could not be reached.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, net.ErrClosed)
+}
+
+// isDialError reports whether the connection to the endpoint was not established.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
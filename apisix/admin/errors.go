@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// StatusError is returned when the Admin API responds with the error status code.
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether the object is not found in APISIX,
+// e.g. when it was deleted outside of Terraform.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusNotFound
+	}
+
+	// The api_client package reports the status code only in the error message
+	return err != nil && strings.HasPrefix(err.Error(), fmt.Sprintf("status: %d,", http.StatusNotFound))
+}
@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+type listItem[T any] struct {
+	Key   string `json:"key"`
+	Value T      `json:"value"`
+}
+
+type listAPIResponse struct {
+	Total int             `json:"total"`
+	List  json.RawMessage `json:"list"`
+}
+
+// ListRoutes - Returns all routes
+func (c *Client) ListRoutes() ([]Route, error) {
+	return list[Route](c, "routes")
+}
+
+// ListServices - Returns all services
+func (c *Client) ListServices() ([]Service, error) {
+	return list[Service](c, "services")
+}
+
+// ListUpstreams - Returns all upstreams
+func (c *Client) ListUpstreams() ([]api_client.Upstream, error) {
+	return list[api_client.Upstream](c, "upstreams")
+}
+
+// ListSSLCertificates - Returns all SSL certificates
+func (c *Client) ListSSLCertificates() ([]SSLCertificate, error) {
+	return list[SSLCertificate](c, "ssls")
+}
+
+// ListConsumers - Returns all consumers
+func (c *Client) ListConsumers() ([]api_client.Consumer, error) {
+	return list[api_client.Consumer](c, "consumers")
+}
+
+// ListConsumerGroups - Returns all consumer groups
+func (c *Client) ListConsumerGroups() ([]api_client.ConsumerGroup, error) {
+	return list[api_client.ConsumerGroup](c, "consumer_groups")
+}
+
+// ListGlobalRules - Returns all global rules
+func (c *Client) ListGlobalRules() ([]api_client.GlobalRule, error) {
+	return list[api_client.GlobalRule](c, "global_rules")
+}
+
+// ListPluginConfigs - Returns all plugin configs
+func (c *Client) ListPluginConfigs() ([]api_client.PluginConfig, error) {
+	return list[api_client.PluginConfig](c, "plugin_configs")
+}
+
+// ListStreamRoutes - Returns all stream routes
+func (c *Client) ListStreamRoutes() ([]StreamRoute, error) {
+	return list[StreamRoute](c, "stream_routes")
+}
+
+// ListProtos - Returns all protos
+func (c *Client) ListProtos() ([]Proto, error) {
+	return list[Proto](c, "protos")
+}
+
+// SecretItem is the secret along with its secret manager and identifier.
+type SecretItem struct {
+	Manager string
+	ID      string
+	Secret  Secret
+}
+
+// ListSecrets - Returns all secrets
+func (c *Client) ListSecrets() ([]SecretItem, error) {
+	items, err := listItems[Secret](c, "secrets")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []SecretItem{}
+	for _, item := range items {
+		// The key is in the form of /apisix/secrets/manager/id
+		manager, secretID, found := strings.Cut(strings.TrimPrefix(item.Key, "/apisix/secrets/"), "/")
+		if !found {
+			return nil, fmt.Errorf("unexpected key of the secret: %s", item.Key)
+		}
+
+		result = append(result, SecretItem{
+			Manager: manager,
+			ID:      secretID,
+			Secret:  item.Value,
+		})
+	}
+
+	return result, nil
+}
+
+func list[T any](c *Client, resource string) ([]T, error) {
+	items, err := listItems[T](c, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []T{}
+	for _, item := range items {
+		result = append(result, item.Value)
+	}
+
+	return result, nil
+}
+
+func listItems[T any](c *Client, resource string) ([]listItem[T], error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/apisix/admin/%s", c.Endpoint, resource), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	listResponse := listAPIResponse{}
+	err = json.Unmarshal(body, &listResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []listItem[T]{}
+
+	// APISIX encodes an empty list as an empty JSON object
+	if len(listResponse.List) == 0 || bytes.Equal(bytes.TrimSpace(listResponse.List), []byte("{}")) {
+		return items, nil
+	}
+
+	err = json.Unmarshal(listResponse.List, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+type objectAPIResponse[T any] struct {
+	Key   string `json:"key"`
+	Value T      `json:"value"`
+}
+
+func getObject[T any](c *Client, resource string) (*T, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/apisix/admin/%s", c.Endpoint, resource), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	getResponse := objectAPIResponse[T]{}
+	err = json.Unmarshal(body, &getResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getResponse.Value, nil
+}
+
+func sendObject[T any](c *Client, method string, resource string, object T) (*T, error) {
+	rb, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/apisix/admin/%s", c.Endpoint, resource), bytes.NewReader(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sendResponse := objectAPIResponse[T]{}
+	err = json.Unmarshal(body, &sendResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sendResponse.Value, nil
+}
+
+func deleteObject(c *Client, resource string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/apisix/admin/%s", c.Endpoint, resource), nil)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	deleteResponse := api_client.DeleteResponse{}
+	err = json.Unmarshal(body, &deleteResponse)
+	if err != nil {
+		return err
+	}
+
+	if deleteResponse.Deleted != "1" {
+		return errors.New(string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// PluginDefaults are the default values of the plugin configuration fields declared in the plugin schema,
+// which APISIX sets when the plugin configuration omits the fields.
+type PluginDefaults struct {
+	// Values lists the default values of each field. A field may have several of them,
+	// e.g. when the schema declares them in the subschemas of the different policies.
+	Values map[string][]interface{}
+	// Properties are the defaults of the fields of the nested objects.
+	Properties map[string]*PluginDefaults
+}
+
+// NewPluginDefaults returns the empty plugin defaults.
+func NewPluginDefaults() *PluginDefaults {
+	return &PluginDefaults{
+		Values:     map[string][]interface{}{},
+		Properties: map[string]*PluginDefaults{},
+	}
+}
+
+// ParsePluginDefaults collects the default values of the fields from the JSON schema of the plugin,
+// including the ones declared in the conditional subschemas, such as the redis policy of limit-count.
+func ParsePluginDefaults(schema json.RawMessage) (*PluginDefaults, error) {
+	var document interface{}
+	err := json.Unmarshal(schema, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := NewPluginDefaults()
+	defaults.collect(document)
+
+	return defaults, nil
+}
+
+// HasDefault reports whether the value is one of the default values of the field.
+func (d *PluginDefaults) HasDefault(field string, value interface{}) bool {
+	if d == nil {
+		return false
+	}
+
+	for _, defaultValue := range d.Values[field] {
+		if reflect.DeepEqual(defaultValue, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Merge adds the default values of the other plugin defaults, e.g. the ones of the consumer schema.
+func (d *PluginDefaults) Merge(other *PluginDefaults) {
+	if other == nil {
+		return
+	}
+
+	for field, values := range other.Values {
+		for _, value := range values {
+			d.addValue(field, value)
+		}
+	}
+
+	for field, properties := range other.Properties {
+		d.properties(field).Merge(properties)
+	}
+}
+
+func (d *PluginDefaults) collect(schema interface{}) {
+	object, ok := schema.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if properties, ok := object["properties"].(map[string]interface{}); ok {
+		for field, property := range properties {
+			propertyObject, ok := property.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if value, ok := propertyObject["default"]; ok {
+				d.addValue(field, value)
+			}
+
+			nested := NewPluginDefaults()
+			nested.collect(propertyObject)
+			if len(nested.Values) > 0 || len(nested.Properties) > 0 {
+				d.properties(field).Merge(nested)
+			}
+		}
+	}
+
+	// The subschemas describe the same object, so their fields belong to it
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if subschemas, ok := object[keyword].([]interface{}); ok {
+			for _, subschema := range subschemas {
+				d.collect(subschema)
+			}
+		}
+	}
+
+	for _, keyword := range []string{"if", "then", "else"} {
+		d.collect(object[keyword])
+	}
+
+	// The dependencies are either the subschemas or the lists of the required fields
+	if dependencies, ok := object["dependencies"].(map[string]interface{}); ok {
+		for _, dependency := range dependencies {
+			d.collect(dependency)
+		}
+	}
+}
+
+func (d *PluginDefaults) addValue(field string, value interface{}) {
+	if !d.HasDefault(field, value) {
+		d.Values[field] = append(d.Values[field], value)
+	}
+}
+
+func (d *PluginDefaults) properties(field string) *PluginDefaults {
+	properties, ok := d.Properties[field]
+	if !ok {
+		properties = NewPluginDefaults()
+		d.Properties[field] = properties
+	}
+
+	return properties
+}
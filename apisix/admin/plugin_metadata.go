@@ -0,0 +1,24 @@
+package admin
+
+// PluginMetadata is the plugin-wide configuration shared by all instances of the plugin.
+type PluginMetadata map[string]interface{}
+
+// GetPluginMetadata - Returns the metadata of the plugin
+func (c *Client) GetPluginMetadata(pluginName string) (*PluginMetadata, error) {
+	return getObject[PluginMetadata](c, "plugin_metadata/"+pluginName)
+}
+
+// CreatePluginMetadata - Creates the metadata of the plugin
+func (c *Client) CreatePluginMetadata(pluginName string, metadata PluginMetadata) (*PluginMetadata, error) {
+	return sendObject(c, "PUT", "plugin_metadata/"+pluginName, metadata)
+}
+
+// UpdatePluginMetadata - Updates the metadata of the plugin
+func (c *Client) UpdatePluginMetadata(pluginName string, metadata PluginMetadata) (*PluginMetadata, error) {
+	return sendObject(c, "PUT", "plugin_metadata/"+pluginName, metadata)
+}
+
+// DeletePluginMetadata - Deletes the metadata of the plugin
+func (c *Client) DeletePluginMetadata(pluginName string) error {
+	return deleteObject(c, "plugin_metadata/"+pluginName)
+}
@@ -0,0 +1,373 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const (
+	// SubsystemHTTP is the subsystem of the plugins of the routes, services and consumers.
+	SubsystemHTTP = "http"
+	// SubsystemStream is the subsystem of the plugins of the stream routes.
+	SubsystemStream = "stream"
+
+	// SchemaTypeRoute is the schema of the plugins configured on the routes, services and other objects.
+	SchemaTypeRoute = ""
+	// SchemaTypeConsumer is the schema of the plugins configured on the consumers, such as the credentials of key-auth.
+	SchemaTypeConsumer = "consumer"
+)
+
+// PluginSchemas caches the plugins available in APISIX and their JSON schemas.
+// The plugins are listed when the provider is configured, and the schemas are fetched
+// on the first use, so only the schemas of the configured plugins are requested.
+// The failures are cached as well, so they are not retried for each resource.
+type PluginSchemas struct {
+	mu         sync.Mutex
+	validate   bool
+	plugins    map[string]map[string]bool
+	pluginsErr error
+	schemas    map[string]*compiledPluginSchema
+	schemaErrs map[string]error
+}
+
+// compiledPluginSchema is the plugin schema along with the default values of the plugin fields.
+type compiledPluginSchema struct {
+	schema   *jsonschema.Schema
+	defaults *PluginDefaults
+}
+
+func newPluginSchemas() *PluginSchemas {
+	return &PluginSchemas{
+		schemas:    map[string]*compiledPluginSchema{},
+		schemaErrs: map[string]error{},
+	}
+}
+
+// ListPlugins - Returns the names of the plugins enabled in APISIX
+func (c *Client) ListPlugins(subsystem string) ([]string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/apisix/admin/plugins/list?subsystem=%s", c.Endpoint, url.QueryEscape(subsystem)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	err = json.Unmarshal(body, &names)
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// PluginAttributes are the attributes of the plugin which are not part of its schema.
+type PluginAttributes struct {
+	Priority *int64 `json:"priority,omitempty"`
+}
+
+// ListPluginAttributes - Returns the attributes of the plugins enabled in APISIX by the plugin name.
+// The `all` query is deprecated in APISIX, but it's the only one returning the plugin priorities
+func (c *Client) ListPluginAttributes(subsystem string) (map[string]PluginAttributes, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/apisix/admin/plugins?all=true&subsystem=%s", c.Endpoint, url.QueryEscape(subsystem)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]PluginAttributes{}
+	err = json.Unmarshal(body, &attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+// GetPluginSchema - Returns the JSON schema of the plugin
+func (c *Client) GetPluginSchema(name string, subsystem string, schemaType string) (json.RawMessage, error) {
+	query := url.Values{}
+	query.Set("subsystem", subsystem)
+	if schemaType != SchemaTypeRoute {
+		query.Set("schema_type", schemaType)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/apisix/admin/schema/plugins/%s?%s", c.Endpoint, url.PathEscape(name), query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// EnablePluginValidation enables the validation of the plugins against the schemas fetched from APISIX.
+// The plugins are listed by LoadPluginSchemas.
+func (c *Client) EnablePluginValidation() {
+	c.pluginSchemas.mu.Lock()
+	defer c.pluginSchemas.mu.Unlock()
+
+	c.pluginSchemas.validate = true
+}
+
+// PluginDefaults returns the default values of the plugin fields declared in the plugin schema.
+// The schema is fetched on the first use, whether the validation of the plugins is enabled or not.
+func (c *Client) PluginDefaults(name string, subsystem string, schemaType string) (*PluginDefaults, error) {
+	schema, err := c.pluginSchema(name, subsystem, schemaType)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.defaults, nil
+}
+
+// LoadPluginSchemas lists the plugins of both subsystems, unless they are already listed.
+// It does nothing unless the validation of the plugins is enabled.
+func (c *Client) LoadPluginSchemas() error {
+	c.pluginSchemas.mu.Lock()
+	loaded := !c.pluginSchemas.validate || c.pluginSchemas.plugins != nil || c.pluginSchemas.pluginsErr != nil
+	pluginsErr := c.pluginSchemas.pluginsErr
+	c.pluginSchemas.mu.Unlock()
+
+	if loaded {
+		return pluginsErr
+	}
+
+	// The plugins are listed without holding the lock, so the requests don't block the validation
+	// of the plugins by the other resources. The first listing completed by the concurrent resources is kept.
+	plugins, err := c.listEnabledPlugins()
+
+	c.pluginSchemas.mu.Lock()
+	defer c.pluginSchemas.mu.Unlock()
+
+	if c.pluginSchemas.plugins != nil || c.pluginSchemas.pluginsErr != nil {
+		return c.pluginSchemas.pluginsErr
+	}
+
+	if err != nil {
+		if !isCanceled(err) {
+			c.pluginSchemas.pluginsErr = err
+		}
+		return err
+	}
+
+	c.pluginSchemas.plugins = plugins
+	return nil
+}
+
+// listEnabledPlugins returns the plugins enabled in APISIX by the subsystem.
+func (c *Client) listEnabledPlugins() (map[string]map[string]bool, error) {
+	plugins := map[string]map[string]bool{}
+	for _, subsystem := range []string{SubsystemHTTP, SubsystemStream} {
+		names, err := c.ListPlugins(subsystem)
+		if err != nil {
+			return nil, err
+		}
+
+		plugins[subsystem] = map[string]bool{}
+		for _, name := range names {
+			plugins[subsystem][name] = true
+		}
+	}
+
+	return plugins, nil
+}
+
+// ValidatePlugin validates the plugin configuration against the schema fetched from APISIX.
+// The validation error is returned as the first value, while the second one reports
+// the schema which can't be fetched or compiled. Both are nil unless the validation of the plugins is enabled.
+func (c *Client) ValidatePlugin(name string, subsystem string, schemaType string, config interface{}) (error, error) {
+	err := c.LoadPluginSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.pluginValidationEnabled() {
+		return nil, nil
+	}
+
+	if !c.pluginEnabled(name, subsystem) {
+		return &UnknownPluginError{Name: name}, nil
+	}
+
+	schema, err := c.pluginSchema(name, subsystem, schemaType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The internal fields, such as _meta, are injected into the schemas in APISIX
+	if object, ok := config.(map[string]interface{}); ok {
+		stripped := map[string]interface{}{}
+		for field, value := range object {
+			if field != "_meta" {
+				stripped[field] = value
+			}
+		}
+		config = stripped
+	}
+
+	// Decode the configuration as the schema validator expects it
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	err = decoder.Decode(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	err = schema.schema.Validate(document)
+	if validationErr, ok := err.(*jsonschema.ValidationError); ok {
+		return &PluginValidationError{causes: validationCauses(validationErr)}, nil
+	}
+
+	return err, nil
+}
+
+// UnknownPluginError reports the plugin which is not enabled in APISIX.
+type UnknownPluginError struct {
+	Name string
+}
+
+func (e *UnknownPluginError) Error() string {
+	return "unknown plugin " + e.Name
+}
+
+// PluginValidationError lists the fields of the plugin configuration which don't match the schema.
+type PluginValidationError struct {
+	causes []string
+}
+
+func (e *PluginValidationError) Error() string {
+	return strings.Join(e.causes, "\n")
+}
+
+// validationCauses returns the messages of the innermost errors, which point to the invalid fields.
+func validationCauses(err *jsonschema.ValidationError) []string {
+	if len(err.Causes) == 0 {
+		location := err.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+
+		return []string{location + ": " + err.Message}
+	}
+
+	var causes []string
+	for _, cause := range err.Causes {
+		causes = append(causes, validationCauses(cause)...)
+	}
+
+	return causes
+}
+
+func (c *Client) pluginValidationEnabled() bool {
+	c.pluginSchemas.mu.Lock()
+	defer c.pluginSchemas.mu.Unlock()
+
+	return c.pluginSchemas.validate
+}
+
+func (c *Client) pluginEnabled(name string, subsystem string) bool {
+	c.pluginSchemas.mu.Lock()
+	defer c.pluginSchemas.mu.Unlock()
+
+	return c.pluginSchemas.plugins[subsystem][name]
+}
+
+func (c *Client) pluginSchema(name string, subsystem string, schemaType string) (*compiledPluginSchema, error) {
+	key := "apisix:///" + subsystem + "/" + schemaType + "/" + name
+
+	c.pluginSchemas.mu.Lock()
+	schema, ok := c.pluginSchemas.schemas[key]
+	schemaErr, failed := c.pluginSchemas.schemaErrs[key]
+	c.pluginSchemas.mu.Unlock()
+
+	if ok {
+		return schema, nil
+	}
+	if failed {
+		return nil, schemaErr
+	}
+
+	// Fetched without holding the lock like the plugins, so the schemas of the other plugins are available meanwhile
+	schema, err := c.compilePluginSchema(key, name, subsystem, schemaType)
+
+	c.pluginSchemas.mu.Lock()
+	defer c.pluginSchemas.mu.Unlock()
+
+	if cached, ok := c.pluginSchemas.schemas[key]; ok {
+		return cached, nil
+	}
+	if cachedErr, ok := c.pluginSchemas.schemaErrs[key]; ok {
+		return nil, cachedErr
+	}
+
+	if err != nil {
+		if !isCanceled(err) {
+			c.pluginSchemas.schemaErrs[key] = err
+		}
+		return nil, err
+	}
+
+	c.pluginSchemas.schemas[key] = schema
+	return schema, nil
+}
+
+func (c *Client) compilePluginSchema(key string, name string, subsystem string, schemaType string) (*compiledPluginSchema, error) {
+	data, err := c.GetPluginSchema(name, subsystem, schemaType)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+
+	err = compiler.AddResource(key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := compiler.Compile(key)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := ParsePluginDefaults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledPluginSchema{schema: schema, defaults: defaults}, nil
+}
+
+// isCanceled reports whether the request was canceled by the context of the Terraform operation,
+// so the failure is not cached and the next operation tries again.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
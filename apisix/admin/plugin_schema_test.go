@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestPluginSchemaNotBlockedByFetch(t *testing.T) {
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apisix/admin/plugins/list":
+			_, _ = w.Write([]byte(`["limit-count", "request-id"]`))
+		case "/apisix/admin/schema/plugins/limit-count":
+			// The slow response of APISIX
+			close(requested)
+			<-release
+			_, _ = w.Write([]byte(`{"type": "object"}`))
+		default:
+			_, _ = w.Write([]byte(`{"type": "object", "properties": {"header_name": {"default": "X-Request-Id"}}}`))
+		}
+	}))
+	defer server.Close()
+	defer func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	}()
+
+	endpoint := server.URL
+	apiKey := "test"
+	apiClient, err := api_client.NewClient(&endpoint, &apiKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(apiClient)
+	client.EnablePluginValidation()
+
+	slow := make(chan error, 1)
+	go func() {
+		_, err := client.PluginDefaults("limit-count", SubsystemHTTP, SchemaTypeRoute)
+		slow <- err
+	}()
+	<-requested
+
+	// The other plugins are validated while the schema of limit-count is fetched
+	done := make(chan error, 1)
+	go func() {
+		err := client.LoadPluginSchemas()
+		if err == nil && !client.pluginEnabled("request-id", SubsystemHTTP) {
+			t.Errorf("expected the request-id plugin to be enabled")
+		}
+		if err == nil {
+			_, err = client.PluginDefaults("request-id", SubsystemHTTP, SchemaTypeRoute)
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the plugin schemas are blocked by the fetch of the other schema")
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
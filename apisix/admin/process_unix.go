@@ -0,0 +1,14 @@
+//go:build !windows
+
+package admin
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processExists reports whether the process is running, even if it is owned by the other user.
+func processExists(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package admin
+
+import (
+	"os"
+)
+
+// processExists reports whether the process is running. FindProcess opens the process on Windows,
+// so it fails once the process exited.
+func processExists(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	process.Release()
+	return true
+}
@@ -0,0 +1,29 @@
+package admin
+
+// Proto is the protobuf definition used by the grpc-transcode plugin.
+type Proto struct {
+	ID      *string            `json:"id,omitempty"`
+	Desc    *string            `json:"desc,omitempty"`
+	Content *string            `json:"content,omitempty"`
+	Labels  *map[string]string `json:"labels,omitempty"`
+}
+
+// GetProto - Returns a specific proto
+func (c *Client) GetProto(protoID string) (*Proto, error) {
+	return getObject[Proto](c, "protos/"+protoID)
+}
+
+// CreateProto - Creates a proto
+func (c *Client) CreateProto(proto Proto) (*Proto, error) {
+	return sendObject(c, "POST", "protos", proto)
+}
+
+// UpdateProto - Updates a proto
+func (c *Client) UpdateProto(protoID string, proto Proto) (*Proto, error) {
+	return sendObject(c, "PUT", "protos/"+protoID, proto)
+}
+
+// DeleteProto - Deletes a proto
+func (c *Client) DeleteProto(protoID string) error {
+	return deleteObject(c, "protos/"+protoID)
+}
@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RetryConfig holds the timeout and retry settings of the Admin API requests.
+type RetryConfig struct {
+	// RequestTimeout limits each attempt of the request. Zero means no timeout.
+	RequestTimeout time.Duration
+	// MaxRetries is the number of retries of the failed idempotent requests.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled on each next one.
+	RetryBackoff time.Duration
+}
+
+// RetryTransport retries the idempotent requests which failed with
+// a connection error or a 5xx response.
+type RetryTransport struct {
+	Config RetryConfig
+	Nested http.RoundTripper
+}
+
+// NewRetryTransport wraps the transport with the timeout and retry settings.
+func NewRetryTransport(config RetryConfig, nested http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		Config: config,
+		Nested: nested,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if isIdempotent(req.Method) {
+		retries = t.Config.MaxRetries
+	}
+
+	backoff := t.Config.RetryBackoff
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		res, err := t.roundTrip(attemptReq)
+		if attempt >= retries || !isRetryable(req, res, err) {
+			return res, err
+		}
+
+		if res != nil {
+			// Drain the body to reuse the connection
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+
+		// Send the copy of the request with the rewound body
+		attemptReq = req.Clone(req.Context())
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+// roundTrip sends a single attempt of the request within the request timeout.
+func (t *RetryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.Config.RequestTimeout <= 0 {
+		return t.Nested.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Config.RequestTimeout)
+	res, err := t.Nested.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Keep the context until the body is read
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// isIdempotent reports whether the request can be safely sent more than once.
+// POST creates a new object on each call, so it is never retried.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryable(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
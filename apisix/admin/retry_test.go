@@ -0,0 +1,275 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc sends the requests with the function, e.g. to fail them with the connection errors.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// recordingServer responds with the statuses in order, then with 200, and records the request bodies.
+type recordingServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func newRecordingServer(t *testing.T, statuses ...int) *recordingServer {
+	server := &recordingServer{statuses: statuses}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		server.mu.Lock()
+		status := http.StatusOK
+		if len(server.bodies) < len(server.statuses) {
+			status = server.statuses[len(server.bodies)]
+		}
+		server.bodies = append(server.bodies, string(body))
+		server.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *recordingServer) attempts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.bodies...)
+}
+
+func TestRetryTransportRetries5xx(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected the status %d, got %d", http.StatusOK, res.StatusCode)
+	}
+	if attempts := len(server.attempts()); attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryTransportMaxRetries(t *testing.T) {
+	server := newRecordingServer(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 1, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected the last status %d, got %d", http.StatusInternalServerError, res.StatusCode)
+	}
+	if attempts := len(server.attempts()); attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryTransportNoRetryOn4xx(t *testing.T) {
+	server := newRecordingServer(t, http.StatusNotFound)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the status %d, got %d", http.StatusNotFound, res.StatusCode)
+	}
+	if attempts := len(server.attempts()); attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRetryTransportRetriesConnectionErrors(t *testing.T) {
+	attempts := 0
+	nested := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("connection refused")
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Millisecond}, nested)}
+
+	res, err := client.Get("http://apisix.invalid/apisix/admin/routes")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryTransportNoRetryOnPost(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	res, err := client.Post(server.URL, "application/json", strings.NewReader(`{"uri": "/"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the status %d, got %d", http.StatusServiceUnavailable, res.StatusCode)
+	}
+	if attempts := len(server.attempts()); attempts != 1 {
+		t.Errorf("expected 1 attempt of the POST request, got %d", attempts)
+	}
+}
+
+func TestRetryTransportRewindsBody(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	// The request created from the strings.Reader has GetBody
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"uri": "/"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	attempts := server.attempts()
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(attempts))
+	}
+	for i, body := range attempts {
+		if body != `{"uri": "/"}` {
+			t.Errorf("expected the whole body in the attempt %d, got %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportNoRetryWithoutGetBody(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	// The body of the unknown reader can't be rewound
+	req, err := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader(`{"uri": "/"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if attempts := len(server.attempts()); attempts != 1 {
+		t.Errorf("expected 1 attempt of the request without GetBody, got %d", attempts)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 2, RetryBackoff: 20 * time.Millisecond}, http.DefaultTransport)}
+
+	start := time.Now()
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	// 20ms before the first retry, doubled to 40ms before the second one
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected at least 60ms of the backoff, got %s", elapsed)
+	}
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{MaxRetries: 3, RetryBackoff: time.Hour}, http.DefaultTransport)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline exceeded error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the backoff to stop with the context, waited %s", elapsed)
+	}
+	if attempts := len(server.attempts()); attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRetryTransportRequestTimeout(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// Exceed the request timeout of the first attempt only
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRetryTransport(RetryConfig{RequestTimeout: 50 * time.Millisecond, MaxRetries: 1, RetryBackoff: time.Millisecond}, http.DefaultTransport)}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer res.Body.Close()
+
+	// The body is read after the round trip, within the request timeout of the attempt
+	body, err := io.ReadAll(res.Body)
+	if err != nil || string(body) != "{}" {
+		t.Fatalf("expected the body of the retried request, got %q, %v", body, err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// Route extends the api_client.Route with the inline upstream.
+type Route struct {
+	api_client.Route
+	Upstream *api_client.Upstream `json:"upstream,omitempty"`
+}
+
+// GetRoute - Returns a specific route
+func (c *Client) GetRoute(routeID string) (*Route, error) {
+	return getObject[Route](c, "routes/"+routeID)
+}
+
+// CreateRoute - Creates a route
+func (c *Client) CreateRoute(route Route) (*Route, error) {
+	return sendObject(c, "POST", "routes/", route)
+}
+
+// UpdateRoute - Updates a route
+func (c *Client) UpdateRoute(routeID string, route Route) (*Route, error) {
+	return sendObject(c, "PUT", "routes/"+routeID, route)
+}
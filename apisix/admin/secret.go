@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"fmt"
+)
+
+// Secret is the configuration of the secret manager.
+// Only the fields of the configured manager are set.
+type Secret struct {
+	ID *string `json:"id,omitempty"`
+
+	// Vault
+	URI       *string `json:"uri,omitempty"`
+	Prefix    *string `json:"prefix,omitempty"`
+	Token     *string `json:"token,omitempty"`
+	Namespace *string `json:"namespace,omitempty"`
+
+	// AWS
+	AccessKeyID     *string `json:"access_key_id,omitempty"`
+	SecretAccessKey *string `json:"secret_access_key,omitempty"`
+	SessionToken    *string `json:"session_token,omitempty"`
+	Region          *string `json:"region,omitempty"`
+	EndpointURL     *string `json:"endpoint_url,omitempty"`
+}
+
+// GetSecret - Returns a specific secret
+func (c *Client) GetSecret(manager string, secretID string) (*Secret, error) {
+	return getObject[Secret](c, fmt.Sprintf("secrets/%s/%s", manager, secretID))
+}
+
+// CreateSecret - Creates a secret
+func (c *Client) CreateSecret(manager string, secretID string, secret Secret) (*Secret, error) {
+	return sendObject(c, "PUT", fmt.Sprintf("secrets/%s/%s", manager, secretID), secret)
+}
+
+// UpdateSecret - Updates a secret
+func (c *Client) UpdateSecret(manager string, secretID string, secret Secret) (*Secret, error) {
+	return sendObject(c, "PUT", fmt.Sprintf("secrets/%s/%s", manager, secretID), secret)
+}
+
+// DeleteSecret - Deletes a secret
+func (c *Client) DeleteSecret(manager string, secretID string) error {
+	return deleteObject(c, fmt.Sprintf("secrets/%s/%s", manager, secretID))
+}
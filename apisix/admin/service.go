@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// Service extends the api_client.Service with the inline upstream.
+type Service struct {
+	api_client.Service
+	Upstream *api_client.Upstream `json:"upstream,omitempty"`
+}
+
+// GetService - Returns a specific service
+func (c *Client) GetService(serviceID string) (*Service, error) {
+	return getObject[Service](c, "services/"+serviceID)
+}
+
+// CreateService - Creates a service
+func (c *Client) CreateService(service Service) (*Service, error) {
+	return sendObject(c, "POST", "services/", service)
+}
+
+// UpdateService - Updates a service
+func (c *Client) UpdateService(serviceID string, service Service) (*Service, error) {
+	return sendObject(c, "PUT", "services/"+serviceID, service)
+}
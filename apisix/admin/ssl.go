@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// SSLCertificate extends the api_client.SSLCertificate with the additional certificates,
+// so the same SNI can be served with both the RSA and ECC certificates.
+type SSLCertificate struct {
+	api_client.SSLCertificate
+	Certificates *[]string  `json:"certs,omitempty"`
+	PrivateKeys  *[]string  `json:"keys,omitempty"`
+	Client       *SSLClient `json:"client,omitempty"`
+}
+
+// SSLClient configures the verification of the client certificates, i.e. the mutual TLS.
+type SSLClient struct {
+	CA               string    `json:"ca"`
+	Depth            *int64    `json:"depth,omitempty"`
+	SkipMTLSURIRegex *[]string `json:"skip_mtls_uri_regex,omitempty"`
+}
+
+// GetSslCertificate - Returns a specific certificate
+func (c *Client) GetSslCertificate(certificateID string) (*SSLCertificate, error) {
+	return getObject[SSLCertificate](c, "ssls/"+certificateID)
+}
+
+// CreateSslCertificate - Creates a certificate
+func (c *Client) CreateSslCertificate(sslCertificate SSLCertificate) (*SSLCertificate, error) {
+	return sendObject(c, "POST", "ssls/", sslCertificate)
+}
+
+// UpdateSslCertificate - Updates a certificate
+func (c *Client) UpdateSslCertificate(certificateID string, sslCertificate SSLCertificate) (*SSLCertificate, error) {
+	return sendObject(c, "PUT", "ssls/"+certificateID, sslCertificate)
+}
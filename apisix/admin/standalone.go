@@ -0,0 +1,387 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StandaloneEndpoint is the placeholder endpoint of the client in the standalone mode.
+const StandaloneEndpoint = "http://standalone"
+
+// standaloneConfigEnd marks the end of the declarative configuration for APISIX.
+const standaloneConfigEnd = "#END\n"
+
+// standaloneSections maps the Admin API resources to the sections of the declarative configuration.
+var standaloneSections = map[string]string{
+	"routes":          "routes",
+	"services":        "services",
+	"upstreams":       "upstreams",
+	"ssls":            "ssls",
+	"consumers":       "consumers",
+	"consumer_groups": "consumer_groups",
+	"global_rules":    "global_rules",
+	"plugin_configs":  "plugin_configs",
+	"plugin_metadata": "plugin_metadata",
+	"stream_routes":   "stream_routes",
+	"protos":          "protos",
+	"secrets":         "secrets",
+}
+
+// StandaloneTransport serves the Admin API requests from the declarative configuration file
+// of APISIX running in the standalone mode. The client calls and the converters stay the same,
+// while the objects are written to the file, such as apisix.yaml, instead of the Admin API.
+type StandaloneTransport struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewStandaloneTransport returns the transport writing the objects to the configuration file.
+func NewStandaloneTransport(path string) *StandaloneTransport {
+	return &StandaloneTransport{
+		path: path,
+	}
+}
+
+type standaloneConfig map[string][]map[string]interface{}
+
+func (t *StandaloneTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, id, _ := strings.Cut(strings.Trim(strings.TrimPrefix(req.URL.Path, "/apisix/admin/"), "/"), "/")
+	section, ok := standaloneSections[resource]
+	if !ok {
+		return standaloneResponse(req, http.StatusNotFound, map[string]string{
+			"error_msg": fmt.Sprintf("%s is not supported in the standalone mode", req.URL.Path),
+		})
+	}
+
+	var object map[string]interface{}
+	if req.Body != nil && req.Body != http.NoBody {
+		err := json.NewDecoder(req.Body).Decode(&object)
+		req.Body.Close()
+		if err != nil {
+			return standaloneResponse(req, http.StatusBadRequest, map[string]string{
+				"error_msg": "invalid request body: " + err.Error(),
+			})
+		}
+	}
+
+	// Consumers are identified by the username
+	idField := "id"
+	if resource == "consumers" {
+		idField = "username"
+	}
+
+	// Serialize the operations of the provider and lock the file against the other processes
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	unlock, err := lockFile(req.Context(), t.path+".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	config, err := t.read()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := config[section]
+	index := -1
+	for i, existing := range objects {
+		if id != "" && fmt.Sprint(existing[idField]) == id {
+			index = i
+			break
+		}
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		if id == "" {
+			list := make([]map[string]interface{}, 0, len(objects))
+			for _, existing := range objects {
+				list = append(list, map[string]interface{}{
+					"key":   standaloneKey(resource, fmt.Sprint(existing[idField])),
+					"value": existing,
+				})
+			}
+			return standaloneResponse(req, http.StatusOK, map[string]interface{}{
+				"total": len(list),
+				"list":  list,
+			})
+		}
+
+		if index < 0 {
+			return standaloneNotFound(req, resource, id)
+		}
+
+		return standaloneResponse(req, http.StatusOK, map[string]interface{}{
+			"key":   standaloneKey(resource, id),
+			"value": objects[index],
+		})
+
+	case http.MethodPut, http.MethodPost:
+		if object == nil {
+			object = map[string]interface{}{}
+		}
+
+		if id == "" {
+			if value, ok := object[idField]; ok {
+				id = fmt.Sprint(value)
+			} else if req.Method == http.MethodPost {
+				id = newStandaloneID(objects, idField)
+			} else {
+				return standaloneResponse(req, http.StatusBadRequest, map[string]string{
+					"error_msg": "missing " + idField,
+				})
+			}
+
+			for i, existing := range objects {
+				if fmt.Sprint(existing[idField]) == id {
+					index = i
+					break
+				}
+			}
+		}
+		object[idField] = id
+
+		status := http.StatusOK
+		if index < 0 {
+			objects = append(objects, object)
+			status = http.StatusCreated
+		} else {
+			objects[index] = object
+		}
+		config[section] = objects
+
+		err = t.write(config)
+		if err != nil {
+			return nil, err
+		}
+
+		return standaloneResponse(req, status, map[string]interface{}{
+			"key":   standaloneKey(resource, id),
+			"value": object,
+		})
+
+	case http.MethodDelete:
+		if index < 0 {
+			return standaloneNotFound(req, resource, id)
+		}
+
+		config[section] = append(objects[:index], objects[index+1:]...)
+		if len(config[section]) == 0 {
+			delete(config, section)
+		}
+
+		err = t.write(config)
+		if err != nil {
+			return nil, err
+		}
+
+		return standaloneResponse(req, http.StatusOK, map[string]interface{}{
+			"key":     standaloneKey(resource, id),
+			"deleted": "1",
+		})
+	}
+
+	return standaloneResponse(req, http.StatusMethodNotAllowed, map[string]string{
+		"error_msg": req.Method + " is not supported in the standalone mode",
+	})
+}
+
+func (t *StandaloneTransport) read() (standaloneConfig, error) {
+	config := standaloneConfig{}
+
+	data, err := os.ReadFile(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", t.path, err)
+	}
+
+	return config, nil
+}
+
+// write replaces the configuration file, so APISIX never reloads the partially written file.
+func (t *StandaloneTransport) write(config standaloneConfig) error {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(config)
+	if err != nil {
+		return err
+	}
+	buffer.WriteString(standaloneConfigEnd)
+
+	file, err := os.CreateTemp(filepath.Dir(t.path), filepath.Base(t.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(buffer.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), t.path)
+}
+
+// staleLockAge is the age after which the lock file without the owner is considered stale,
+// e.g. when the process crashed before writing the owner.
+const staleLockAge = 10 * time.Second
+
+// lockFile creates the lock file holding the owner process, waiting while it is held by the other process.
+// The lock left by the crashed process on the same host is removed at once, instead of waiting
+// until the timeout of the Terraform operation.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, err = file.WriteString(lockOwner())
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+
+			return func() { os.Remove(path) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if removeStaleLock(path) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("could not lock %s, remove it if no other Terraform process is running: %w", path, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// lockOwner identifies the process holding the lock by its PID and host.
+func lockOwner() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%d %s\n", os.Getpid(), hostname)
+}
+
+// removeStaleLock removes the lock file whose owner is no longer running,
+// and reports whether the lock can be acquired again.
+func removeStaleLock(path string) bool {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true
+	}
+	if err != nil || !lockStale(path, data) {
+		return false
+	}
+
+	// Don't remove the lock acquired by the other process in the meantime
+	current, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(current, data) {
+		return false
+	}
+
+	return os.Remove(path) == nil
+}
+
+// lockStale reports whether the owner of the lock is no longer running. The owner running
+// on the other host can't be checked, so its lock is only released by the owner itself.
+func lockStale(path string, data []byte) bool {
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		info, err := os.Stat(path)
+		return err == nil && time.Since(info.ModTime()) > staleLockAge
+	}
+
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return false
+	}
+
+	hostname, _ := os.Hostname()
+	if fields[1] != hostname {
+		return false
+	}
+
+	// The operations of this process are serialized, so its own lock is left by the previous run
+	return pid == os.Getpid() || !processExists(pid)
+}
+
+// newStandaloneID generates the identifier of the object, as APISIX does on POST.
+func newStandaloneID(objects []map[string]interface{}, idField string) string {
+	for id := time.Now().UnixNano(); ; id++ {
+		candidate := strconv.FormatInt(id, 10)
+
+		exists := false
+		for _, existing := range objects {
+			if fmt.Sprint(existing[idField]) == candidate {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			return candidate
+		}
+	}
+}
+
+func standaloneKey(resource string, id string) string {
+	return "/apisix/" + resource + "/" + id
+}
+
+func standaloneNotFound(req *http.Request, resource string, id string) (*http.Response, error) {
+	return standaloneResponse(req, http.StatusNotFound, map[string]string{
+		"message": fmt.Sprintf("Key not found: %s", standaloneKey(resource, id)),
+	})
+}
+
+func standaloneResponse(req *http.Request, status int, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,290 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/holubovskyi/apisix-client-go"
+	"gopkg.in/yaml.v3"
+)
+
+// standaloneRequest sends the Admin API request to the standalone transport and decodes the response.
+func standaloneRequest(t *testing.T, ctx context.Context, transport *StandaloneTransport, method string, path string, body string) (int, map[string]interface{}, error) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, StandaloneEndpoint+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	var decoded map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&decoded)
+	if err != nil {
+		t.Fatalf("could not decode the response: %s", err)
+	}
+
+	return res.StatusCode, decoded, nil
+}
+
+func TestStandaloneTransport(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "apisix.yaml")
+	transport := NewStandaloneTransport(path)
+
+	// Create with the generated identifier
+	status, body, err := standaloneRequest(t, ctx, transport, http.MethodPost, "/apisix/admin/routes", `{"uri": "/generated"}`)
+	if err != nil || status != http.StatusCreated {
+		t.Fatalf("expected the route to be created, got %d, %v", status, err)
+	}
+	generatedID := body["value"].(map[string]interface{})["id"].(string)
+	if body["key"] != "/apisix/routes/"+generatedID {
+		t.Errorf("unexpected key %v of the route %s", body["key"], generatedID)
+	}
+
+	// Create and update with the configured identifier
+	status, _, err = standaloneRequest(t, ctx, transport, http.MethodPut, "/apisix/admin/routes/1", `{"uri": "/one"}`)
+	if err != nil || status != http.StatusCreated {
+		t.Fatalf("expected the route 1 to be created, got %d, %v", status, err)
+	}
+	status, _, err = standaloneRequest(t, ctx, transport, http.MethodPut, "/apisix/admin/routes/1", `{"uri": "/updated"}`)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected the route 1 to be updated, got %d, %v", status, err)
+	}
+
+	// Consumers are identified by the username
+	status, _, err = standaloneRequest(t, ctx, transport, http.MethodPut, "/apisix/admin/consumers", `{"username": "jack"}`)
+	if err != nil || status != http.StatusCreated {
+		t.Fatalf("expected the consumer to be created, got %d, %v", status, err)
+	}
+
+	status, body, err = standaloneRequest(t, ctx, transport, http.MethodGet, "/apisix/admin/routes/1", "")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected the route 1, got %d, %v", status, err)
+	}
+	if uri := body["value"].(map[string]interface{})["uri"]; uri != "/updated" {
+		t.Errorf("expected the updated uri, got %v", uri)
+	}
+
+	status, body, err = standaloneRequest(t, ctx, transport, http.MethodGet, "/apisix/admin/routes", "")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected the routes, got %d, %v", status, err)
+	}
+	if total := body["total"]; total != float64(2) {
+		t.Errorf("expected 2 routes, got %v", total)
+	}
+
+	status, _, err = standaloneRequest(t, ctx, transport, http.MethodGet, "/apisix/admin/consumers/jack", "")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected the consumer jack, got %d, %v", status, err)
+	}
+
+	// The declarative configuration is written for APISIX
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), standaloneConfigEnd) {
+		t.Errorf("expected the configuration to end with %q, got:\n%s", standaloneConfigEnd, data)
+	}
+	var config standaloneConfig
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		t.Fatalf("could not parse the written configuration: %s", err)
+	}
+	if len(config["routes"]) != 2 || len(config["consumers"]) != 1 {
+		t.Errorf("unexpected configuration:\n%s", data)
+	}
+
+	// Delete
+	status, _, err = standaloneRequest(t, ctx, transport, http.MethodDelete, "/apisix/admin/routes/1", "")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected the route 1 to be deleted, got %d, %v", status, err)
+	}
+	status, _, err = standaloneRequest(t, ctx, transport, http.MethodDelete, "/apisix/admin/routes/"+generatedID, "")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected the route %s to be deleted, got %d, %v", generatedID, status, err)
+	}
+
+	config, err = transport.read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config["routes"]; ok {
+		t.Errorf("expected the empty routes section to be removed, got %v", config["routes"])
+	}
+}
+
+func TestStandaloneTransportNotFound(t *testing.T) {
+	ctx := context.Background()
+	transport := NewStandaloneTransport(filepath.Join(t.TempDir(), "apisix.yaml"))
+
+	testCases := map[string]struct {
+		method string
+		path   string
+	}{
+		"get missing object":    {method: http.MethodGet, path: "/apisix/admin/routes/missing"},
+		"delete missing object": {method: http.MethodDelete, path: "/apisix/admin/upstreams/missing"},
+		"unsupported resource":  {method: http.MethodGet, path: "/apisix/admin/schema/plugins/limit-count"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			status, _, err := standaloneRequest(t, ctx, transport, testCase.method, testCase.path, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if status != http.StatusNotFound {
+				t.Errorf("expected the status %d, got %d", http.StatusNotFound, status)
+			}
+		})
+	}
+
+	// The 404 responses are recognized by the client, so the missing objects are removed from the state
+	endpoint := StandaloneEndpoint
+	apiKey := ""
+	apiClient, err := api_client.NewClient(&endpoint, &apiKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiClient.HTTPClient = &http.Client{Transport: transport}
+
+	_, err = NewClient(apiClient).GetProto("missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected the not found error, got %v", err)
+	}
+}
+
+func TestStandaloneTransportAtomicWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "apisix.yaml")
+	transport := NewStandaloneTransport(path)
+
+	for i := 0; i < 3; i++ {
+		status, _, err := standaloneRequest(t, context.Background(), transport, http.MethodPut, fmt.Sprintf("/apisix/admin/upstreams/%d", i), `{"type": "roundrobin"}`)
+		if err != nil || status != http.StatusCreated {
+			t.Fatalf("expected the upstream %d to be created, got %d, %v", i, status, err)
+		}
+	}
+
+	// Neither the temporary files nor the lock are left next to the configuration
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "apisix.yaml" {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("expected only apisix.yaml, got %v", names)
+	}
+}
+
+func TestNewStandaloneID(t *testing.T) {
+	// The identifiers generated in a row may share the timestamp, which is skipped once taken
+	objects := []map[string]interface{}{}
+	for i := 0; i < 100; i++ {
+		id := newStandaloneID(objects, "id")
+		for _, existing := range objects {
+			if existing["id"] == id {
+				t.Fatalf("generated the existing identifier %s", id)
+			}
+		}
+		objects = append(objects, map[string]interface{}{"id": id})
+	}
+}
+
+func TestLockFileContention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apisix.yaml.lock")
+
+	// The lock held by the running process on this host, the parent of the test
+	hostname, _ := os.Hostname()
+	err := os.WriteFile(path, []byte(fmt.Sprintf("%d %s\n", os.Getppid(), hostname)), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, err = lockFile(ctx, path)
+	if err == nil || !strings.Contains(err.Error(), "could not lock") {
+		t.Fatalf("expected the lock to be held, got %v", err)
+	}
+}
+
+func TestLockFileStale(t *testing.T) {
+	// The PID of the exited process
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	err := cmd.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exitedPID := cmd.Process.Pid
+
+	hostname, _ := os.Hostname()
+
+	testCases := map[string]struct {
+		owner   string
+		modTime time.Time
+	}{
+		"crashed owner":      {owner: fmt.Sprintf("%d %s\n", exitedPID, hostname)},
+		"previous run":       {owner: fmt.Sprintf("%d %s\n", os.Getpid(), hostname)},
+		"old lock w/o owner": {owner: "", modTime: time.Now().Add(-time.Minute)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "apisix.yaml.lock")
+			err := os.WriteFile(path, []byte(testCase.owner), 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !testCase.modTime.IsZero() {
+				err = os.Chtimes(path, testCase.modTime, testCase.modTime)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Acquired at once instead of waiting until the timeout
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			unlock, err := lockFile(ctx, path)
+			if err != nil {
+				t.Fatalf("expected the stale lock to be taken over, got %s", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil || string(data) != lockOwner() {
+				t.Errorf("expected the lock to be owned by this process, got %q, %v", data, err)
+			}
+
+			unlock()
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("expected the lock to be removed, got %v", err)
+			}
+		})
+	}
+}
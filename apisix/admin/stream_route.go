@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// StreamRoute extends the api_client.StreamRoute with the plugins, inline upstream,
+// service and xRPC protocol.
+type StreamRoute struct {
+	api_client.StreamRoute
+	Plugins   *map[string]interface{} `json:"plugins,omitempty"`
+	Upstream  *api_client.Upstream    `json:"upstream,omitempty"`
+	ServiceId *string                 `json:"service_id,omitempty"`
+	Protocol  *StreamRouteProtocol    `json:"protocol,omitempty"`
+}
+
+// StreamRouteProtocol is the xRPC protocol of the stream route.
+type StreamRouteProtocol struct {
+	Name string                  `json:"name"`
+	Conf *map[string]interface{} `json:"conf,omitempty"`
+}
+
+// GetStreamRoute - Returns a specific stream route
+func (c *Client) GetStreamRoute(routeID string) (*StreamRoute, error) {
+	return getObject[StreamRoute](c, "stream_routes/"+routeID)
+}
+
+// CreateStreamRoute - Creates a stream route
+func (c *Client) CreateStreamRoute(route StreamRoute) (*StreamRoute, error) {
+	return sendObject(c, "POST", "stream_routes/", route)
+}
+
+// UpdateStreamRoute - Updates a stream route
+func (c *Client) UpdateStreamRoute(routeID string, route StreamRoute) (*StreamRoute, error) {
+	return sendObject(c, "PUT", "stream_routes/"+routeID, route)
+}
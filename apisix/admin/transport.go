@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// TLSConfig holds the TLS settings of the Admin API connection.
+type TLSConfig struct {
+	// CACertificate is the PEM-encoded CA certificate used to verify the Admin API.
+	CACertificate string
+	// ClientCertificate is the PEM-encoded client certificate used for mTLS.
+	ClientCertificate string
+	// ClientKey is the PEM-encoded private key of the client certificate.
+	ClientKey string
+	// InsecureSkipVerify disables the verification of the Admin API certificate.
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns the HTTP transport configured with the TLS settings.
+func NewTransport(config TLSConfig) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.CACertificate)) {
+			return nil, fmt.Errorf("the CA certificate does not contain any valid PEM-encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertificate != "" || config.ClientKey != "" {
+		if config.ClientCertificate == "" || config.ClientKey == "" {
+			return nil, fmt.Errorf("both the client certificate and the client key must be provided")
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCertificate), []byte(config.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
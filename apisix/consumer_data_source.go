@@ -0,0 +1,103 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &consumerDataSource{}
+	_ datasource.DataSourceWithConfigure = &consumerDataSource{}
+)
+
+// NewConsumerDataSource is a helper function to simplify the provider implementation.
+func NewConsumerDataSource() datasource.DataSource {
+	return &consumerDataSource{}
+}
+
+// consumerDataSource is the data source implementation.
+type consumerDataSource struct {
+	client *admin.Client
+}
+
+// Metadata returns the data source type name.
+func (d *consumerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consumer"
+}
+
+// Schema defines the schema for the data source.
+func (d *consumerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dataSourceSchema, err := model.ConsumerDataSourceSchema()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Data Source Schema",
+			"Could not build the consumer data source schema, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Schema = dataSourceSchema
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *consumerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *consumerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the consumer data source read")
+	// Get the consumer lookup configuration
+	var username types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("username"), &username)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get consumer from the APISIX
+	consumerResponse, err := d.client.GetConsumer(username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Consumer",
+			"Could not read APISIX Consumer by name "+username.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state, diags := model.ConsumerFromApiToTerraform(ctx, consumerResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = model.SetDataSourceState(ctx, &resp.State, model.ConsumerSchema, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,40 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConsumerGroupDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_consumer_group" "test" {
+	id   = "ds001"
+	desc = "Example of the consumer group data source"
+	plugins = jsonencode(
+		{
+			prometheus = {
+				prefer_name = false
+			}
+		}
+	)
+}
+
+data "apisix_consumer_group" "test" {
+	id = apisix_consumer_group.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_consumer_group.test", "id", "apisix_consumer_group.test", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_consumer_group.test", "desc", "apisix_consumer_group.test", "desc"),
+				),
+			},
+		},
+	})
+}
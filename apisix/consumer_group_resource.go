@@ -4,21 +4,22 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &consumerGroupResource{}
-	_ resource.ResourceWithConfigure   = &consumerGroupResource{}
-	_ resource.ResourceWithImportState = &consumerGroupResource{}
+	_ resource.Resource                     = &consumerGroupResource{}
+	_ resource.ResourceWithConfigure        = &consumerGroupResource{}
+	_ resource.ResourceWithImportState      = &consumerGroupResource{}
+	_ resource.ResourceWithConfigValidators = &consumerGroupResource{}
+	_ resource.ResourceWithModifyPlan       = &consumerGroupResource{}
 )
 
 // NewConsumerGroupResource is a helper function to simplify the provider implementation.
@@ -28,7 +29,7 @@ func NewConsumerGroupResource() resource.Resource {
 
 // consumerGroupResource is the resource implementation.
 type consumerGroupResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +42,34 @@ func (r *consumerGroupResource) Schema(_ context.Context, _ resource.SchemaReque
 	resp.Schema = model.ConsumerGroupSchema
 }
 
+// Validate Config
+func (r *consumerGroupResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("plugins"),
+			path.MatchRoot("typed_plugins"),
+		),
+		model.TypedPluginsConfigValidator(),
+	}
+}
+
+// ModifyPlan validates the plugins against the plugin schemas of APISIX.
+func (r *consumerGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validatePlugins(ctx, r.client, req.Plan, admin.SubsystemHTTP, admin.SchemaTypeRoute)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *consumerGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,11 +88,26 @@ func (r *consumerGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	newConsumerGroupRequest := model.ConsumerGroupFromTerraformToApi(ctx, &plan)
+	newConsumerGroupRequest, diags := model.ConsumerGroupFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create new consumer group
-	newConsumerGroupResponse, err := r.client.CreateConsumerGroup(plan.ID.ValueString(), newConsumerGroupRequest)
+	newConsumerGroupResponse, err := client.CreateConsumerGroup(plan.ID.ValueString(), newConsumerGroupRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Consumer Group",
@@ -85,11 +117,25 @@ func (r *consumerGroupResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	newState := model.ConsumerGroupFromApiToTerraform(ctx, newConsumerGroupResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.ConsumerGroupFromApiToTerraform(ctx, newConsumerGroupResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -109,9 +155,27 @@ func (r *consumerGroupResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed consumer group from the APISIX
-	consumerGroupStateResponse, err := r.client.GetConsumerGroup(state.ID.ValueString())
+	consumerGroupStateResponse, err := client.GetConsumerGroup(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The consumer group is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Consumer Group",
 			"Could not read APISIX Consumer Group by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -120,11 +184,25 @@ func (r *consumerGroupResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	// Overwrite with refreshed state
-	newState := model.ConsumerGroupFromApiToTerraform(ctx, consumerGroupStateResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+	newState, diags := model.ConsumerGroupFromApiToTerraform(ctx, consumerGroupStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, state.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,11 +222,26 @@ func (r *consumerGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	updateConsumerGroupRequest := model.ConsumerGroupFromTerraformToApi(ctx, &plan)
+	updateConsumerGroupRequest, diags := model.ConsumerGroupFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update existing consumer group
-	_, err := r.client.UpdateConsumerGroup(plan.ID.ValueString(), updateConsumerGroupRequest)
+	_, err := client.UpdateConsumerGroup(plan.ID.ValueString(), updateConsumerGroupRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Consumer Group",
@@ -158,7 +251,7 @@ func (r *consumerGroupResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	// Fetch updated consumer group
-	updatedConsumerGroup, err := r.client.GetConsumerGroup(plan.ID.ValueString())
+	updatedConsumerGroup, err := client.GetConsumerGroup(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Consumer Group",
@@ -167,11 +260,25 @@ func (r *consumerGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	newState := model.ConsumerGroupFromApiToTerraform(ctx, updatedConsumerGroup)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.ConsumerGroupFromApiToTerraform(ctx, updatedConsumerGroup)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,8 +298,18 @@ func (r *consumerGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete the consumer group
-	err := r.client.DeleteConsumerGroup(state.ID.ValueString())
+	err := client.DeleteConsumerGroup(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Consumer Group",
@@ -205,6 +322,15 @@ func (r *consumerGroupResource) Delete(ctx context.Context, req resource.DeleteR
 // Import resource into state
 func (r *consumerGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the consumer group importing")
+	// Validate the import ID and check the consumer group exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Consumer Group", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetConsumerGroup(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
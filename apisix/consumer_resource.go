@@ -4,21 +4,21 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &consumerResource{}
-	_ resource.ResourceWithConfigure   = &consumerResource{}
-	_ resource.ResourceWithImportState = &consumerResource{}
+	_ resource.Resource                     = &consumerResource{}
+	_ resource.ResourceWithConfigure        = &consumerResource{}
+	_ resource.ResourceWithImportState      = &consumerResource{}
+	_ resource.ResourceWithConfigValidators = &consumerResource{}
+	_ resource.ResourceWithModifyPlan       = &consumerResource{}
 )
 
 // NewConsumerResource is a helper function to simplify the provider implementation.
@@ -28,7 +28,7 @@ func NewConsumerResource() resource.Resource {
 
 // consumerResource is the resource implementation.
 type consumerResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +41,30 @@ func (r *consumerResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	resp.Schema = model.ConsumerSchema
 }
 
+// Validate Config
+func (r *consumerResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		model.TypedPluginsConfigValidator(),
+	}
+}
+
+// ModifyPlan validates the plugins against the plugin schemas of APISIX.
+func (r *consumerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validatePlugins(ctx, r.client, req.Plan, admin.SubsystemHTTP, admin.SchemaTypeConsumer)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *consumerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,11 +83,26 @@ func (r *consumerResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	newConsumerRequest := model.ConsumerFromTerraformToApi(ctx, &plan)
+	newConsumerRequest, diags := model.ConsumerFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create new consumer
-	newConsumerResponse, err := r.client.CreateConsumer(newConsumerRequest)
+	newConsumerResponse, err := client.CreateConsumer(newConsumerRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Consumer",
@@ -85,11 +112,25 @@ func (r *consumerResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	newState := model.ConsumerFromApiToTerraform(ctx, newConsumerResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.ConsumerFromApiToTerraform(ctx, newConsumerResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeConsumer)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -109,9 +150,27 @@ func (r *consumerResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed service from the APISIX
-	consumerStateResponse, err := r.client.GetConsumer(state.Username.ValueString())
+	consumerStateResponse, err := client.GetConsumer(state.Username.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The consumer is not found in APISIX, removing it from the state", map[string]any{
+				"Username": state.Username.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Consumer",
 			"Could not read APISIX Consumer by name "+state.Username.ValueString()+": "+err.Error(),
@@ -120,11 +179,25 @@ func (r *consumerResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Overwrite with refreshed state
-	newState := model.ConsumerFromApiToTerraform(ctx, consumerStateResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+	newState, diags := model.ConsumerFromApiToTerraform(ctx, consumerStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeConsumer)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, state.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,11 +217,26 @@ func (r *consumerResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	updateConsumerRequest := model.ConsumerFromTerraformToApi(ctx, &plan)
+	updateConsumerRequest, diags := model.ConsumerFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update existing consumer
-	_, err := r.client.UpdateConsumer(updateConsumerRequest)
+	_, err := client.UpdateConsumer(updateConsumerRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Consumer",
@@ -158,7 +246,7 @@ func (r *consumerResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Fetch updated consumer
-	updatedConsumer, err := r.client.GetConsumer(plan.Username.ValueString())
+	updatedConsumer, err := client.GetConsumer(plan.Username.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Consumer",
@@ -167,11 +255,25 @@ func (r *consumerResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	newState := model.ConsumerFromApiToTerraform(ctx, updatedConsumer)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.ConsumerFromApiToTerraform(ctx, updatedConsumer)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeConsumer)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -192,8 +294,18 @@ func (r *consumerResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete the consumer
-	err := r.client.DeleteConsumer(state.Username.ValueString())
+	err := client.DeleteConsumer(state.Username.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Consumer",
@@ -206,6 +318,15 @@ func (r *consumerResource) Delete(ctx context.Context, req resource.DeleteReques
 // Import resource into state
 func (r *consumerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the consumer importing")
+	// Validate the import user name and check the consumer exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Consumer", req.ID, model.ValidateConsumerUsername, func(client *admin.Client) error {
+		_, err := client.GetConsumer(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import user name and save to 'username' attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("username"), req, resp)
 }
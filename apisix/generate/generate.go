@@ -0,0 +1,341 @@
+// Package generate renders the Terraform configuration of the objects in an existing APISIX instance,
+// so they can be brought under the management of the provider with the import blocks.
+package generate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// labelRegex matches the characters which can't be used in the resource names.
+var labelRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// object is the APISIX object converted to the resource model.
+type object struct {
+	// importID is the identifier accepted by the import of the resource.
+	importID string
+	// name is the preferred name of the resource, such as the name of the route.
+	name string
+	// model is the pointer to the resource model.
+	model interface{}
+}
+
+// kind describes how the objects are listed and converted to the resource models.
+type kind struct {
+	resourceType string
+	schema       schema.Schema
+	list         func(ctx context.Context, client *admin.Client) ([]object, diag.Diagnostics)
+}
+
+// kinds are ordered so the referenced objects, such as the upstreams, come first.
+// The plugin metadata is not listed, as the Admin API doesn't support listing it.
+var kinds = []kind{
+	{
+		resourceType: "apisix_upstream",
+		schema:       model.UpstreamSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			upstreams, err := client.ListUpstreams()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Upstreams", err.Error())
+				return nil, diags
+			}
+
+			for i := range upstreams {
+				upstream, upstreamDiags := model.UpstreamFromApiToTerraform(ctx, &upstreams[i])
+				diags.Append(upstreamDiags...)
+				objects = append(objects, object{importID: upstream.ID.ValueString(), name: upstream.Name.ValueString(), model: &upstream})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_service",
+		schema:       model.ServiceSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			services, err := client.ListServices()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Services", err.Error())
+				return nil, diags
+			}
+
+			for i := range services {
+				service, serviceDiags := model.ServiceFromApiToTerraform(ctx, &services[i])
+				diags.Append(serviceDiags...)
+				objects = append(objects, object{importID: service.ID.ValueString(), name: service.Name.ValueString(), model: &service})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_ssl_certificate",
+		schema:       model.SSLCertificateSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			certificates, err := client.ListSSLCertificates()
+			if err != nil {
+				diags.AddError("Error Listing APISIX SSL Certificates", err.Error())
+				return nil, diags
+			}
+
+			for i := range certificates {
+				certificate := model.SSLCertificateFromAPIToTerraform(ctx, &certificates[i])
+				if certificates[i].PrivateKeys != nil {
+					// The encrypted keys only mark the attribute to be replaced with the variable
+					certificate.AdditionalPrivateKeys, _ = types.ListValueFrom(ctx, types.StringType, certificates[i].PrivateKeys)
+				}
+				objects = append(objects, object{importID: certificate.ID.ValueString(), model: &certificate})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_consumer_group",
+		schema:       model.ConsumerGroupSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			groups, err := client.ListConsumerGroups()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Consumer Groups", err.Error())
+				return nil, diags
+			}
+
+			for i := range groups {
+				group, groupDiags := model.ConsumerGroupFromApiToTerraform(ctx, &groups[i])
+				diags.Append(groupDiags...)
+				objects = append(objects, object{importID: group.ID.ValueString(), model: &group})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_consumer",
+		schema:       model.ConsumerSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			consumers, err := client.ListConsumers()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Consumers", err.Error())
+				return nil, diags
+			}
+
+			for i := range consumers {
+				consumer, consumerDiags := model.ConsumerFromApiToTerraform(ctx, &consumers[i])
+				diags.Append(consumerDiags...)
+				objects = append(objects, object{importID: consumer.Username.ValueString(), model: &consumer})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_plugin_config",
+		schema:       model.PluginConfigSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			configs, err := client.ListPluginConfigs()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Plugin Configs", err.Error())
+				return nil, diags
+			}
+
+			for i := range configs {
+				config, configDiags := model.PluginConfigFromApiToTerraform(ctx, &configs[i])
+				diags.Append(configDiags...)
+				objects = append(objects, object{importID: config.ID.ValueString(), model: &config})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_global_rule",
+		schema:       model.GlobalRuleSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			rules, err := client.ListGlobalRules()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Global Rules", err.Error())
+				return nil, diags
+			}
+
+			for i := range rules {
+				rule, ruleDiags := model.GlobalRuleFromApiToTerraform(ctx, &rules[i])
+				diags.Append(ruleDiags...)
+				objects = append(objects, object{importID: rule.ID.ValueString(), model: &rule})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_proto",
+		schema:       model.ProtoSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			protos, err := client.ListProtos()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Protos", err.Error())
+				return nil, diags
+			}
+
+			for i := range protos {
+				proto, protoDiags := model.ProtoFromApiToTerraform(ctx, &protos[i], types.StringNull())
+				diags.Append(protoDiags...)
+				objects = append(objects, object{importID: proto.ID.ValueString(), model: &proto})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_secret",
+		schema:       model.SecretSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			secrets, err := client.ListSecrets()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Secrets", err.Error())
+				return nil, diags
+			}
+
+			for i := range secrets {
+				secret := model.SecretFromApiToTerraform(ctx, secrets[i].Manager, secrets[i].ID, &secrets[i].Secret)
+				objects = append(objects, object{importID: secret.ID.ValueString(), name: secrets[i].ID, model: &secret})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_route",
+		schema:       model.RouteSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			routes, err := client.ListRoutes()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Routes", err.Error())
+				return nil, diags
+			}
+
+			for i := range routes {
+				route, routeDiags := model.RouteFromApiToTerraform(ctx, &routes[i])
+				diags.Append(routeDiags...)
+				objects = append(objects, object{importID: route.ID.ValueString(), name: route.Name.ValueString(), model: &route})
+			}
+
+			return objects, diags
+		},
+	},
+	{
+		resourceType: "apisix_stream_route",
+		schema:       model.StreamRouteSchema,
+		list: func(ctx context.Context, client *admin.Client) (objects []object, diags diag.Diagnostics) {
+			routes, err := client.ListStreamRoutes()
+			if err != nil {
+				diags.AddError("Error Listing APISIX Stream Routes", err.Error())
+				return nil, diags
+			}
+
+			for i := range routes {
+				route, routeDiags := model.StreamRouteFromApiToTerraform(ctx, &routes[i])
+				diags.Append(routeDiags...)
+				objects = append(objects, object{importID: route.ID.ValueString(), model: &route})
+			}
+
+			return objects, diags
+		},
+	},
+}
+
+// Generate lists the objects in APISIX and writes the resources along with the import blocks.
+// The sensitive attributes, such as the private keys, are not returned by APISIX in plain text,
+// so they are replaced with the references to the generated variables.
+func Generate(ctx context.Context, client *admin.Client, output io.Writer) error {
+	var variables, resources bytes.Buffer
+	labels := map[string]bool{}
+
+	for _, kind := range kinds {
+		objects, diags := kind.list(ctx, client)
+		if diags.HasError() {
+			return diagnosticsError(diags)
+		}
+
+		sort.Slice(objects, func(i, j int) bool {
+			return objects[i].importID < objects[j].importID
+		})
+
+		for _, object := range objects {
+			label := resourceLabel(kind.resourceType, object, labels)
+
+			state := tfsdk.State{Schema: kind.schema}
+			diags = state.Set(ctx, object.model)
+			if diags.HasError() {
+				return diagnosticsError(diags)
+			}
+
+			fmt.Fprintf(&resources, "import {\n  to = %s.%s\n  id = %s\n}\n\n", kind.resourceType, label, quoteString(object.importID))
+			fmt.Fprintf(&resources, "resource %s %s {\n", quoteString(kind.resourceType), quoteString(label))
+
+			w := writer{
+				buffer: &resources,
+				sensitive: func(attributePath []string, variableType string) string {
+					name := strings.TrimPrefix(kind.resourceType, "apisix_") + "_" + label + "_" + strings.Join(attributePath, "_")
+					fmt.Fprintf(&variables, "variable %s {\n  type      = %s\n  sensitive = true\n}\n\n", quoteString(name), variableType)
+					return "var." + name
+				},
+			}
+
+			err := w.writeAttributes(kind.schema.Attributes, state.Raw, 1, nil)
+			if err != nil {
+				return fmt.Errorf("could not render %s %s: %w", kind.resourceType, object.importID, err)
+			}
+
+			resources.WriteString("}\n\n")
+		}
+	}
+
+	// Align the attributes as terraform fmt does
+	_, err := output.Write(hclwrite.Format(append(variables.Bytes(), resources.Bytes()...)))
+	return err
+}
+
+// resourceLabel returns the unique name of the resource based on the name or the identifier of the object.
+func resourceLabel(resourceType string, object object, labels map[string]bool) string {
+	name := object.name
+	if name == "" {
+		name = object.importID
+	}
+
+	label := strings.Trim(labelRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || !(label[0] == '_' || (label[0] >= 'a' && label[0] <= 'z')) {
+		label = strings.TrimPrefix(resourceType, "apisix_") + "_" + label
+	}
+
+	unique := label
+	for i := 2; labels[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	labels[resourceType+"."+unique] = true
+
+	return unique
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags.Errors() {
+		messages = append(messages, d.Summary()+": "+d.Detail())
+	}
+
+	return errors.New(strings.Join(messages, "\n"))
+}
@@ -0,0 +1,78 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/holubovskyi/apisix-client-go"
+
+	"terraform-provider-apisix/apisix/admin"
+)
+
+func TestGenerate(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+
+	endpoint := "http://127.0.0.1:9180"
+	apiKey := "edd1c9f034335f136f87ad84b625c8f1"
+
+	apiClient, err := api_client.NewClient(&endpoint, &apiKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := admin.NewClient(apiClient)
+
+	upstreamType := "roundrobin"
+	upstreamName := "Generated Upstream"
+	_, err = client.UpdateUpstream("generated", api_client.Upstream{
+		Name: &upstreamName,
+		Type: &upstreamType,
+		Nodes: &[]api_client.UpstreamNodeType{
+			{Host: "127.0.0.1", Port: 1980, Weight: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteUpstream("generated")
+
+	uri := "/generated/${path}"
+	upstreamID := "generated"
+	plugins := map[string]interface{}{
+		"ip-restriction": map[string]interface{}{
+			"blacklist": []interface{}{"10.0.0.0/8"},
+		},
+	}
+	route := admin.Route{}
+	route.URI = &uri
+	route.UpstreamId = &upstreamID
+	route.Plugins = &plugins
+	_, err = client.UpdateRoute("generated", route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteRoute("generated")
+
+	var output bytes.Buffer
+	err = Generate(context.Background(), client, &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"import {\n  to = apisix_upstream.generated_upstream\n  id = \"generated\"\n}",
+		"resource \"apisix_upstream\" \"generated_upstream\" {\n  id   = \"generated\"\n  name = \"Generated Upstream\"",
+		"resource \"apisix_route\" \"generated\" {",
+		"uri         = \"/generated/$${path}\"",
+		"upstream_id = \"generated\"",
+		"plugins = jsonencode({\n    ip-restriction = {\n      blacklist = [\"10.0.0.0/8\"]\n    }\n  })",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("expected %q in the generated configuration:\n%s", expected, output.String())
+		}
+	}
+}
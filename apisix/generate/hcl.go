@@ -0,0 +1,367 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// identifierRegex matches the keys, which don't need quoting in the HCL objects.
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// hclKeywords can't be used as the unquoted object keys.
+var hclKeywords = map[string]bool{
+	"true":  true,
+	"false": true,
+	"null":  true,
+	"for":   true,
+	"in":    true,
+	"if":    true,
+}
+
+// sensitiveValue is called for the sensitive attributes, which are replaced with the variable references
+// of the given type, such as `string` or `list(string)`.
+type sensitiveValue func(attributePath []string, variableType string) string
+
+// writer renders the attributes of the resource in the HCL syntax.
+type writer struct {
+	buffer    *bytes.Buffer
+	sensitive sensitiveValue
+}
+
+// writeAttributes renders the configurable attributes of the object. The computed only attributes
+// and null values are skipped, so the resource configuration contains only the meaningful values.
+func (w *writer) writeAttributes(attributes map[string]schema.Attribute, value tftypes.Value, indent int, attributePath []string) error {
+	var values map[string]tftypes.Value
+	err := value.As(&values)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range attributeNames(attributes) {
+		attribute := attributes[name]
+		attributeValue, ok := values[name]
+		if !ok || !(attribute.IsRequired() || attribute.IsOptional()) {
+			continue
+		}
+
+		currentPath := append(append([]string{}, attributePath...), name)
+
+		if attribute.IsSensitive() && (attribute.IsRequired() || !attributeValue.IsNull()) {
+			fmt.Fprintf(w.buffer, "%s%s = %s\n", strings.Repeat("  ", indent), name, w.sensitive(currentPath, variableType(attribute)))
+			continue
+		}
+
+		if attributeValue.IsNull() || !attributeValue.IsKnown() {
+			continue
+		}
+
+		fmt.Fprintf(w.buffer, "%s%s = ", strings.Repeat("  ", indent), name)
+		err = w.writeAttribute(attribute, attributeValue, indent, currentPath)
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(currentPath, "."), err)
+		}
+		w.buffer.WriteString("\n")
+	}
+
+	return nil
+}
+
+func (w *writer) writeAttribute(attribute schema.Attribute, value tftypes.Value, indent int, attributePath []string) error {
+	switch nested := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		return w.writeObject(nested.Attributes, value, indent, attributePath)
+	case schema.ListNestedAttribute:
+		return w.writeObjects(nested.NestedObject.Attributes, value, indent, attributePath)
+	case schema.SetNestedAttribute:
+		return w.writeObjects(nested.NestedObject.Attributes, value, indent, attributePath)
+	case schema.MapNestedAttribute:
+		var elements map[string]tftypes.Value
+		err := value.As(&elements)
+		if err != nil {
+			return err
+		}
+
+		w.buffer.WriteString("{\n")
+		for _, key := range sortedKeys(elements) {
+			fmt.Fprintf(w.buffer, "%s%s = ", strings.Repeat("  ", indent+1), objectKey(key))
+			err = w.writeObject(nested.NestedObject.Attributes, elements[key], indent+1, append(append([]string{}, attributePath...), key))
+			if err != nil {
+				return err
+			}
+			w.buffer.WriteString("\n")
+		}
+		w.buffer.WriteString(strings.Repeat("  ", indent) + "}")
+		return nil
+	}
+
+	switch attribute.GetType().(type) {
+	case model.JSONType, model.PluginsType:
+		var data string
+		err := value.As(&data)
+		if err != nil {
+			return err
+		}
+
+		return w.writeJSONEncode(data, indent)
+	}
+
+	return w.writeValue(value, indent)
+}
+
+func (w *writer) writeObject(attributes map[string]schema.Attribute, value tftypes.Value, indent int, attributePath []string) error {
+	w.buffer.WriteString("{\n")
+	err := w.writeAttributes(attributes, value, indent+1, attributePath)
+	if err != nil {
+		return err
+	}
+	w.buffer.WriteString(strings.Repeat("  ", indent) + "}")
+
+	return nil
+}
+
+func (w *writer) writeObjects(attributes map[string]schema.Attribute, value tftypes.Value, indent int, attributePath []string) error {
+	var elements []tftypes.Value
+	err := value.As(&elements)
+	if err != nil {
+		return err
+	}
+
+	w.buffer.WriteString("[\n")
+	for _, element := range elements {
+		w.buffer.WriteString(strings.Repeat("  ", indent+1))
+		err = w.writeObject(attributes, element, indent+1, attributePath)
+		if err != nil {
+			return err
+		}
+		w.buffer.WriteString(",\n")
+	}
+	w.buffer.WriteString(strings.Repeat("  ", indent) + "]")
+
+	return nil
+}
+
+// variableType returns the type of the variable replacing the sensitive attribute.
+func variableType(attribute schema.Attribute) string {
+	if _, ok := attribute.(schema.ListAttribute); ok {
+		return "list(string)"
+	}
+
+	return "string"
+}
+
+// writeValue renders the primitive values and the collections of them.
+func (w *writer) writeValue(value tftypes.Value, indent int) error {
+	switch {
+	case value.IsNull():
+		w.buffer.WriteString("null")
+		return nil
+	case value.Type().Is(tftypes.String):
+		var data string
+		err := value.As(&data)
+		if err != nil {
+			return err
+		}
+
+		w.writeString(data)
+		return nil
+	case value.Type().Is(tftypes.Number):
+		var data big.Float
+		err := value.As(&data)
+		if err != nil {
+			return err
+		}
+
+		w.buffer.WriteString(data.Text('f', -1))
+		return nil
+	case value.Type().Is(tftypes.Bool):
+		var data bool
+		err := value.As(&data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w.buffer, "%t", data)
+		return nil
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		err := value.As(&elements)
+		if err != nil {
+			return err
+		}
+
+		w.buffer.WriteString("[")
+		for i, element := range elements {
+			if i > 0 {
+				w.buffer.WriteString(", ")
+			}
+			err = w.writeValue(element, indent)
+			if err != nil {
+				return err
+			}
+		}
+		w.buffer.WriteString("]")
+		return nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+		err := value.As(&elements)
+		if err != nil {
+			return err
+		}
+
+		w.buffer.WriteString("{\n")
+		for _, key := range sortedKeys(elements) {
+			fmt.Fprintf(w.buffer, "%s%s = ", strings.Repeat("  ", indent+1), objectKey(key))
+			err = w.writeValue(elements[key], indent+1)
+			if err != nil {
+				return err
+			}
+			w.buffer.WriteString("\n")
+		}
+		w.buffer.WriteString(strings.Repeat("  ", indent) + "}")
+		return nil
+	}
+
+	return fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// writeJSONEncode renders the JSON document as the jsonencode call, as in the examples of the provider.
+func (w *writer) writeJSONEncode(data string, indent int) error {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	err := decoder.Decode(&document)
+	if err != nil {
+		return err
+	}
+
+	w.buffer.WriteString("jsonencode(")
+	w.writeJSON(document, indent)
+	w.buffer.WriteString(")")
+
+	return nil
+}
+
+func (w *writer) writeJSON(document interface{}, indent int) {
+	switch document := document.(type) {
+	case nil:
+		w.buffer.WriteString("null")
+	case bool:
+		fmt.Fprintf(w.buffer, "%t", document)
+	case json.Number:
+		w.buffer.WriteString(document.String())
+	case string:
+		w.writeString(document)
+	case []interface{}:
+		w.buffer.WriteString("[")
+		for i, element := range document {
+			if i > 0 {
+				w.buffer.WriteString(", ")
+			}
+			w.writeJSON(element, indent)
+		}
+		w.buffer.WriteString("]")
+	case map[string]interface{}:
+		if len(document) == 0 {
+			w.buffer.WriteString("{}")
+			return
+		}
+
+		w.buffer.WriteString("{\n")
+		for _, key := range sortedKeys(document) {
+			fmt.Fprintf(w.buffer, "%s%s = ", strings.Repeat("  ", indent+1), objectKey(key))
+			w.writeJSON(document[key], indent+1)
+			w.buffer.WriteString("\n")
+		}
+		w.buffer.WriteString(strings.Repeat("  ", indent) + "}")
+	}
+}
+
+// writeString renders the string, using the heredoc for the multiline values,
+// such as the certificates, when the heredoc keeps the value unchanged.
+func (w *writer) writeString(data string) {
+	if strings.Count(data, "\n") > 1 && strings.HasSuffix(data, "\n") && !strings.Contains(data, "\r") {
+		marker := "EOT"
+		for strings.Contains("\n"+data, "\n"+marker+"\n") {
+			marker += "_"
+		}
+
+		fmt.Fprintf(w.buffer, "<<%s\n%s%s", marker, escapeTemplate(data), marker)
+		return
+	}
+
+	w.buffer.WriteString(quoteString(data))
+}
+
+// quoteString returns the quoted HCL string, escaping the template sequences.
+func quoteString(data string) string {
+	var result strings.Builder
+	result.WriteString(`"`)
+
+	for _, r := range escapeTemplate(data) {
+		switch r {
+		case '\\':
+			result.WriteString(`\\`)
+		case '"':
+			result.WriteString(`\"`)
+		case '\n':
+			result.WriteString(`\n`)
+		case '\r':
+			result.WriteString(`\r`)
+		case '\t':
+			result.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&result, `\u%04x`, r)
+			} else {
+				result.WriteRune(r)
+			}
+		}
+	}
+
+	result.WriteString(`"`)
+	return result.String()
+}
+
+// escapeTemplate escapes the interpolation and directive sequences of the HCL templates.
+func escapeTemplate(data string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(data)
+}
+
+func objectKey(key string) string {
+	if identifierRegex.MatchString(key) && !hclKeywords[key] {
+		return key
+	}
+
+	return quoteString(key)
+}
+
+// attributeNames returns the attribute names with the identifier first, followed by the rest in alphabetical order.
+func attributeNames(attributes map[string]schema.Attribute) []string {
+	names := sortedKeys(attributes)
+	sort.SliceStable(names, func(i, j int) bool {
+		return names[i] == "id" && names[j] != "id"
+	})
+
+	return names
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,103 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &globalRuleDataSource{}
+	_ datasource.DataSourceWithConfigure = &globalRuleDataSource{}
+)
+
+// NewGlobalRuleDataSource is a helper function to simplify the provider implementation.
+func NewGlobalRuleDataSource() datasource.DataSource {
+	return &globalRuleDataSource{}
+}
+
+// globalRuleDataSource is the data source implementation.
+type globalRuleDataSource struct {
+	client *admin.Client
+}
+
+// Metadata returns the data source type name.
+func (d *globalRuleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_rule"
+}
+
+// Schema defines the schema for the data source.
+func (d *globalRuleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dataSourceSchema, err := model.GlobalRuleDataSourceSchema()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Data Source Schema",
+			"Could not build the global rule data source schema, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Schema = dataSourceSchema
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *globalRuleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *globalRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the global rule data source read")
+	// Get the global rule lookup configuration
+	var id types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("id"), &id)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get global rule from the APISIX
+	globalRuleResponse, err := d.client.GetGlobalRule(id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Global Rule",
+			"Could not read APISIX Global Rule by ID "+id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state, diags := model.GlobalRuleFromApiToTerraform(ctx, globalRuleResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = model.SetDataSourceState(ctx, &resp.State, model.GlobalRuleSchema, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
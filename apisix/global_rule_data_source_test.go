@@ -0,0 +1,38 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestGlobalRuleDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_global_rule" "test" {
+	id = "ds001"
+	plugins = jsonencode(
+		{
+			prometheus = {
+				prefer_name = true
+			}
+		}
+	)
+}
+
+data "apisix_global_rule" "test" {
+	id = apisix_global_rule.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_global_rule.test", "id", "apisix_global_rule.test", "id"),
+				),
+			},
+		},
+	})
+}
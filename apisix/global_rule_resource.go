@@ -4,21 +4,22 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &globalRuleResource{}
-	_ resource.ResourceWithConfigure   = &globalRuleResource{}
-	_ resource.ResourceWithImportState = &globalRuleResource{}
+	_ resource.Resource                     = &globalRuleResource{}
+	_ resource.ResourceWithConfigure        = &globalRuleResource{}
+	_ resource.ResourceWithImportState      = &globalRuleResource{}
+	_ resource.ResourceWithConfigValidators = &globalRuleResource{}
+	_ resource.ResourceWithModifyPlan       = &globalRuleResource{}
 )
 
 // NewGlobalRuleResource is a helper function to simplify the provider implementation.
@@ -28,7 +29,7 @@ func NewGlobalRuleResource() resource.Resource {
 
 // globalRuleResource is the resource implementation.
 type globalRuleResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +42,34 @@ func (r *globalRuleResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	resp.Schema = model.GlobalRuleSchema
 }
 
+// Validate Config
+func (r *globalRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("plugins"),
+			path.MatchRoot("typed_plugins"),
+		),
+		model.TypedPluginsConfigValidator(),
+	}
+}
+
+// ModifyPlan validates the plugins against the plugin schemas of APISIX.
+func (r *globalRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validatePlugins(ctx, r.client, req.Plan, admin.SubsystemHTTP, admin.SchemaTypeRoute)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *globalRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,11 +88,26 @@ func (r *globalRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	newGlobalRuleRequest := model.GlobalRuleFromTerraformToApi(ctx, &plan)
+	newGlobalRuleRequest, diags := model.GlobalRuleFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create new global rule
-	newGlobalRuleReponse, err := r.client.CreateGlobalRule(plan.ID.ValueString(), newGlobalRuleRequest)
+	newGlobalRuleReponse, err := client.CreateGlobalRule(plan.ID.ValueString(), newGlobalRuleRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Global Rule",
@@ -85,11 +117,25 @@ func (r *globalRuleResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	newState := model.GlobalRuleFromApiToTerraform(ctx, newGlobalRuleReponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.GlobalRuleFromApiToTerraform(ctx, newGlobalRuleReponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -109,9 +155,27 @@ func (r *globalRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed global rule from the APISIX
-	globalRuleStateResponse, err := r.client.GetGlobalRule(state.ID.ValueString())
+	globalRuleStateResponse, err := client.GetGlobalRule(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The global rule is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Global Rule",
 			"Could not read APISIX Global Rule by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -120,11 +184,25 @@ func (r *globalRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Overwrite with refreshed state
-	newState := model.GlobalRuleFromApiToTerraform(ctx, globalRuleStateResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+	newState, diags := model.GlobalRuleFromApiToTerraform(ctx, globalRuleStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, state.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,11 +222,26 @@ func (r *globalRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	updateGlobalRuleRequest := model.GlobalRuleFromTerraformToApi(ctx, &plan)
+	updateGlobalRuleRequest, diags := model.GlobalRuleFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update existing rule
-	_, err := r.client.UpdateGlobalRule(plan.ID.ValueString(), updateGlobalRuleRequest)
+	_, err := client.UpdateGlobalRule(plan.ID.ValueString(), updateGlobalRuleRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Global Rule",
@@ -158,7 +251,7 @@ func (r *globalRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Fetch updated rule
-	updatedGlobalRule, err := r.client.GetGlobalRule(plan.ID.ValueString())
+	updatedGlobalRule, err := client.GetGlobalRule(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Global Rule",
@@ -167,11 +260,25 @@ func (r *globalRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	newState := model.GlobalRuleFromApiToTerraform(ctx, updatedGlobalRule)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.GlobalRuleFromApiToTerraform(ctx, updatedGlobalRule)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,8 +298,18 @@ func (r *globalRuleResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete the global rule
-	err := r.client.DeleteGlobalRule(state.ID.ValueString())
+	err := client.DeleteGlobalRule(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Global Rule",
@@ -205,6 +322,15 @@ func (r *globalRuleResource) Delete(ctx context.Context, req resource.DeleteRequ
 // Import resource into state
 func (r *globalRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the rule importing")
+	// Validate the import ID and check the global rule exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Global Rule", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetGlobalRule(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,73 @@
+package apisix
+
+import (
+	"context"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// validateImport checks the import identifier and reads the object from APISIX,
+// so the import fails early instead of the following refresh.
+func validateImport(ctx context.Context, client *admin.Client, kind string, id string, validate func(id string) error, get func(client *admin.Client) error) (diags diag.Diagnostics) {
+	err := validate(id)
+	if err != nil {
+		diags.AddError(
+			"Invalid Import Identifier",
+			err.Error(),
+		)
+		return diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	err = get(client.WithContext(ctx))
+	if admin.IsNotFound(err) {
+		diags.AddError(
+			"Cannot Import Non-Existent APISIX "+kind,
+			"APISIX "+kind+" "+id+" does not exist. Check the identifier of the imported object.",
+		)
+		return diags
+	}
+	if err != nil {
+		tflog.Error(ctx, "Error reading the imported object", map[string]any{
+			"Error": err,
+			"ID":    id,
+		})
+		diags.AddError(
+			"Error Importing APISIX "+kind,
+			"Could not read APISIX "+kind+" "+id+": "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// validateCreate checks no object exists with the configured identifier before the object is created,
+// so an existing object is imported instead of being overwritten by the created one.
+func validateCreate(client *admin.Client, kind string, resourceType string, id string, get func(client *admin.Client) error) (diags diag.Diagnostics) {
+	err := get(client)
+	if admin.IsNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Creating APISIX "+kind,
+			"Could not check whether APISIX "+kind+" "+id+" already exists: "+err.Error(),
+		)
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("id"),
+		"APISIX "+kind+" Already Exists",
+		"APISIX "+kind+" "+id+" already exists and would be overwritten. "+
+			"Import it instead, e.g. terraform import "+resourceType+".<name> "+id+", or configure another identifier.",
+	)
+
+	return diags
+}
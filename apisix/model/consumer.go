@@ -5,20 +5,27 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // ConsumerResourceModel maps the resource schema data.
 type ConsumerResourceModel struct {
-	Username    types.String `tfsdk:"username"`
-	Description types.String `tfsdk:"desc"`
-	Labels      types.Map    `tfsdk:"labels"`
-	Plugins     types.String `tfsdk:"plugins"`
-	GroupId     types.String `tfsdk:"group_id"`
+	Username     types.String   `tfsdk:"username"`
+	Description  types.String   `tfsdk:"desc"`
+	Labels       types.Map      `tfsdk:"labels"`
+	Plugins      PluginsValue   `tfsdk:"plugins"`
+	TypedPlugins types.Object   `tfsdk:"typed_plugins"`
+	GroupId      types.String   `tfsdk:"group_id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 var ConsumerSchema = schema.Schema{
@@ -41,44 +48,63 @@ var ConsumerSchema = schema.Schema{
 			Optional:    true,
 		},
 		"plugins": schema.StringAttribute{
+			CustomType:  PluginsType{},
 			Description: "Plugins that are executed during the request/response cycle.",
 			Optional:    true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
 		},
+		"typed_plugins": TypedPluginsSchemaAttribute,
 		"group_id": schema.StringAttribute{
 			Description: "Group of the Consumer.",
 			Optional:    true,
 		},
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// ConsumerDataSourceSchema returns the schema of the consumer data source.
+func ConsumerDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(ConsumerSchema, "username", "Retrieves an APISIX consumer by its username.")
 }
 
-func ConsumerFromTerraformToApi(ctx context.Context, terraformDataModel *ConsumerResourceModel) (apiDataModel api_client.Consumer) {
+func ConsumerFromTerraformToApi(ctx context.Context, terraformDataModel *ConsumerResourceModel) (apiDataModel api_client.Consumer, diags diag.Diagnostics) {
 	apiDataModel.Username = terraformDataModel.Username.ValueStringPointer()
 	apiDataModel.Description = terraformDataModel.Description.ValueStringPointer()
 	apiDataModel.GroupId = terraformDataModel.GroupId.ValueStringPointer()
 
 	_ = terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
 
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	plugins, pluginsDiags = TypedPluginsMerge(ctx, plugins, terraformDataModel.TypedPlugins)
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
 
 	tflog.Debug(ctx, "Result of ConsumerFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func ConsumerFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Consumer) (terraformDataModel ConsumerResourceModel) {
+func ConsumerFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Consumer) (terraformDataModel ConsumerResourceModel, diags diag.Diagnostics) {
 	terraformDataModel.Username = types.StringPointerValue(apiDataModel.Username)
 	terraformDataModel.Description = types.StringPointerValue(apiDataModel.Description)
 	terraformDataModel.GroupId = types.StringPointerValue(apiDataModel.GroupId)
 
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
 
-	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.Plugins, diags = PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	terraformDataModel.TypedPlugins = NewTypedPluginsNull()
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of ConsumerFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
 }
@@ -5,19 +5,26 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // ConsumerGroupResourceModel maps the resource schema data.
 type ConsumerGroupResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Description types.String `tfsdk:"desc"`
-	Labels      types.Map    `tfsdk:"labels"`
-	Plugins     types.String `tfsdk:"plugins"`
+	ID           types.String   `tfsdk:"id"`
+	Description  types.String   `tfsdk:"desc"`
+	Labels       types.Map      `tfsdk:"labels"`
+	Plugins      PluginsValue   `tfsdk:"plugins"`
+	TypedPlugins types.Object   `tfsdk:"typed_plugins"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 var ConsumerGroupSchema = schema.Schema{
@@ -40,34 +47,53 @@ var ConsumerGroupSchema = schema.Schema{
 			Optional:    true,
 		},
 		"plugins": schema.StringAttribute{
+			CustomType:  PluginsType{},
 			Description: "Plugins that are executed during the request/response cycle.",
-			Required:    true,
+			Optional:    true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
 		},
+		"typed_plugins": TypedPluginsSchemaAttribute,
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// ConsumerGroupDataSourceSchema returns the schema of the consumer group data source.
+func ConsumerGroupDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(ConsumerGroupSchema, "id", "Retrieves an APISIX consumer group by its identifier.")
 }
 
-func ConsumerGroupFromTerraformToApi(ctx context.Context, terraformDataModel *ConsumerGroupResourceModel) (apiDataModel api_client.ConsumerGroup) {
+func ConsumerGroupFromTerraformToApi(ctx context.Context, terraformDataModel *ConsumerGroupResourceModel) (apiDataModel api_client.ConsumerGroup, diags diag.Diagnostics) {
 	apiDataModel.ID = terraformDataModel.ID.ValueStringPointer()
 	apiDataModel.Description = terraformDataModel.Description.ValueStringPointer()
 	terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	plugins, pluginsDiags = TypedPluginsMerge(ctx, plugins, terraformDataModel.TypedPlugins)
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
 
 	tflog.Debug(ctx, "Result of the ConsumerGroupFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func ConsumerGroupFromApiToTerraform(ctx context.Context, apiDataModel *api_client.ConsumerGroup) (terraformDataModel ConsumerGroupResourceModel) {
+func ConsumerGroupFromApiToTerraform(ctx context.Context, apiDataModel *api_client.ConsumerGroup) (terraformDataModel ConsumerGroupResourceModel, diags diag.Diagnostics) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
 	terraformDataModel.Description = types.StringPointerValue(apiDataModel.Description)
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
-	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.Plugins, diags = PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	terraformDataModel.TypedPlugins = NewTypedPluginsNull()
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of the ConsumerGroupFromApiToTerraform", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
 }
@@ -0,0 +1,313 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dataSourceExcludedAttributes lists the resource attributes which the data sources never populate,
+// as APISIX returns the typed plugins within the plugins JSON.
+var dataSourceExcludedAttributes = []string{"typed_plugins"}
+
+// DataSourceSchemaFromResourceSchema builds a data source schema that exposes the same
+// attributes as the given resource schema, except for the excluded ones. The lookup attribute
+// becomes required, while all other attributes are computed from the APISIX API response.
+func DataSourceSchemaFromResourceSchema(resourceSchema schema.Schema, lookupAttribute string, description string, excludedAttributes ...string) (dsschema.Schema, error) {
+	attributes, err := dataSourceAttributes(resourceSchema.Attributes, dataSourceExclusions(excludedAttributes))
+	if err != nil {
+		return dsschema.Schema{}, err
+	}
+
+	if attribute, ok := attributes[lookupAttribute].(dsschema.StringAttribute); ok {
+		attribute.Required = true
+		attribute.Computed = false
+		attributes[lookupAttribute] = attribute
+	}
+
+	return dsschema.Schema{
+		Description: description,
+		Attributes:  attributes,
+	}, nil
+}
+
+// DataSourceListAttributeFromResourceSchema builds a computed list attribute whose elements
+// expose the same attributes as the given resource schema, except for the excluded ones.
+func DataSourceListAttributeFromResourceSchema(resourceSchema schema.Schema, description string, excludedAttributes ...string) (dsschema.ListNestedAttribute, error) {
+	attributes, err := dataSourceAttributes(resourceSchema.Attributes, dataSourceExclusions(excludedAttributes))
+	if err != nil {
+		return dsschema.ListNestedAttribute{}, err
+	}
+
+	return dsschema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: dsschema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+	}, nil
+}
+
+func dataSourceExclusions(excludedAttributes []string) map[string]bool {
+	excluded := make(map[string]bool, len(dataSourceExcludedAttributes)+len(excludedAttributes))
+	for _, name := range dataSourceExcludedAttributes {
+		excluded[name] = true
+	}
+	for _, name := range excludedAttributes {
+		excluded[name] = true
+	}
+
+	return excluded
+}
+
+func dataSourceAttributes(resourceAttributes map[string]schema.Attribute, excluded map[string]bool) (map[string]dsschema.Attribute, error) {
+	result := make(map[string]dsschema.Attribute, len(resourceAttributes))
+
+	for name, resourceAttribute := range resourceAttributes {
+		if excluded[name] {
+			continue
+		}
+
+		attribute, err := dataSourceAttribute(resourceAttribute)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+
+		result[name] = attribute
+	}
+
+	return result, nil
+}
+
+func dataSourceAttribute(resourceAttribute schema.Attribute) (dsschema.Attribute, error) {
+	switch attribute := resourceAttribute.(type) {
+	case schema.StringAttribute:
+		return dsschema.StringAttribute{
+			CustomType:          attribute.CustomType,
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			Computed:            true,
+		}, nil
+	case schema.Int64Attribute:
+		return dsschema.Int64Attribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			Computed:            true,
+		}, nil
+	case schema.Float64Attribute:
+		return dsschema.Float64Attribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			Computed:            true,
+		}, nil
+	case schema.BoolAttribute:
+		return dsschema.BoolAttribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			Computed:            true,
+		}, nil
+	case schema.ListAttribute:
+		return dsschema.ListAttribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			ElementType:         attribute.ElementType,
+			Computed:            true,
+		}, nil
+	case schema.MapAttribute:
+		return dsschema.MapAttribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			ElementType:         attribute.ElementType,
+			Computed:            true,
+		}, nil
+	case schema.SingleNestedAttribute:
+		attributes, err := dataSourceAttributes(attribute.Attributes, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return dsschema.SingleNestedAttribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			Attributes:          attributes,
+			Computed:            true,
+		}, nil
+	case schema.ListNestedAttribute:
+		attributes, err := dataSourceAttributes(attribute.NestedObject.Attributes, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return dsschema.ListNestedAttribute{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Sensitive:           attribute.Sensitive,
+			NestedObject: dsschema.NestedAttributeObject{
+				Attributes: attributes,
+			},
+			Computed: true,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported resource schema attribute type %T", resourceAttribute)
+}
+
+// SetDataSourceState writes the resource model into the data source state. The data source
+// schema exposes a subset of the resource schema, so the attributes it lacks, such as the
+// timeouts, are dropped.
+func SetDataSourceState(ctx context.Context, state *tfsdk.State, resourceSchema schema.Schema, val any) diag.Diagnostics {
+	raw, diags := dataSourceTerraformValue(ctx, resourceSchema, state.Schema.Type().TerraformType(ctx), val)
+	if diags.HasError() {
+		return diags
+	}
+
+	state.Raw = raw
+
+	return diags
+}
+
+// DataSourceListValue converts the resource models into the value of the list attribute
+// built by DataSourceListAttributeFromResourceSchema.
+func DataSourceListValue[T any](ctx context.Context, resourceSchema schema.Schema, listType attr.Type, models []T) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elementType := listType.(attr.TypeWithElementType).ElementType().TerraformType(ctx)
+
+	elements := make([]tftypes.Value, 0, len(models))
+	for i := range models {
+		element, elementDiags := dataSourceTerraformValue(ctx, resourceSchema, elementType, &models[i])
+		diags.Append(elementDiags...)
+		if diags.HasError() {
+			return types.ListNull(listType.(attr.TypeWithElementType).ElementType()), diags
+		}
+
+		elements = append(elements, element)
+	}
+
+	value, err := listType.ValueFromTerraform(ctx, tftypes.NewValue(listType.TerraformType(ctx), elements))
+	if err != nil {
+		diags.AddError(
+			"Error Converting Data Source List",
+			"Could not convert the data source list, unexpected error: "+err.Error(),
+		)
+		return types.ListNull(listType.(attr.TypeWithElementType).ElementType()), diags
+	}
+
+	return value.(types.List), diags
+}
+
+func dataSourceTerraformValue(ctx context.Context, resourceSchema schema.Schema, targetType tftypes.Type, val any) (tftypes.Value, diag.Diagnostics) {
+	data := tfsdk.State{Schema: resourceSchema}
+	diags := data.Set(ctx, val)
+	if diags.HasError() {
+		return tftypes.NewValue(targetType, nil), diags
+	}
+
+	raw, err := projectTerraformValue(data.Raw, targetType)
+	if err != nil {
+		diags.AddError(
+			"Error Converting Data Source Value",
+			"Could not convert the resource data into the data source data, unexpected error: "+err.Error(),
+		)
+		return tftypes.NewValue(targetType, nil), diags
+	}
+
+	return raw, diags
+}
+
+// projectTerraformValue returns the value with only the object attributes of the target type.
+func projectTerraformValue(value tftypes.Value, targetType tftypes.Type) (tftypes.Value, error) {
+	if value.IsNull() {
+		return tftypes.NewValue(targetType, nil), nil
+	}
+
+	if !value.IsKnown() {
+		return tftypes.NewValue(targetType, tftypes.UnknownValue), nil
+	}
+
+	switch target := targetType.(type) {
+	case tftypes.Object:
+		attributes := map[string]tftypes.Value{}
+		err := value.As(&attributes)
+		if err != nil {
+			return value, err
+		}
+
+		result := make(map[string]tftypes.Value, len(target.AttributeTypes))
+		for name, attributeType := range target.AttributeTypes {
+			attribute, ok := attributes[name]
+			if !ok {
+				return value, fmt.Errorf("missing attribute %q", name)
+			}
+
+			result[name], err = projectTerraformValue(attribute, attributeType)
+			if err != nil {
+				return value, err
+			}
+		}
+
+		return tftypes.NewValue(target, result), nil
+	case tftypes.List:
+		elements, err := projectTerraformElements(value, target.ElementType)
+		if err != nil {
+			return value, err
+		}
+
+		return tftypes.NewValue(target, elements), nil
+	case tftypes.Set:
+		elements, err := projectTerraformElements(value, target.ElementType)
+		if err != nil {
+			return value, err
+		}
+
+		return tftypes.NewValue(target, elements), nil
+	case tftypes.Map:
+		elements := map[string]tftypes.Value{}
+		err := value.As(&elements)
+		if err != nil {
+			return value, err
+		}
+
+		for key, element := range elements {
+			elements[key], err = projectTerraformValue(element, target.ElementType)
+			if err != nil {
+				return value, err
+			}
+		}
+
+		return tftypes.NewValue(target, elements), nil
+	}
+
+	return value, nil
+}
+
+func projectTerraformElements(value tftypes.Value, elementType tftypes.Type) ([]tftypes.Value, error) {
+	elements := []tftypes.Value{}
+	err := value.As(&elements)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, element := range elements {
+		elements[i], err = projectTerraformValue(element, elementType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return elements, nil
+}
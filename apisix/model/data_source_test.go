@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDataSourceSchemaFromResourceSchemaExclusions(t *testing.T) {
+	dataSourceSchema, err := SSLCertificateDataSourceSchema()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, name := range []string{"private_key", "additional_private_keys", "min_days_remaining"} {
+		if _, ok := dataSourceSchema.Attributes[name]; ok {
+			t.Errorf("expected the %s attribute to be excluded", name)
+		}
+	}
+	if _, ok := dataSourceSchema.Attributes["certificate"]; !ok {
+		t.Error("expected the certificate attribute to be kept")
+	}
+	if !dataSourceSchema.Attributes["id"].IsRequired() {
+		t.Error("expected the lookup attribute to be required")
+	}
+
+	dataSourceSchema, err = RouteDataSourceSchema()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := dataSourceSchema.Attributes["typed_plugins"]; ok {
+		t.Error("expected the typed_plugins attribute to be excluded")
+	}
+	if _, ok := dataSourceSchema.Attributes["plugins"]; !ok {
+		t.Error("expected the plugins attribute to be kept")
+	}
+}
+
+func TestDataSourceSchemaFromResourceSchemaUnsupportedAttribute(t *testing.T) {
+	resourceSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+
+	_, err := DataSourceSchemaFromResourceSchema(resourceSchema, "id", "Test data source.")
+	if err == nil {
+		t.Fatal("expected an error instead of a panic deriving the unsupported attribute")
+	}
+
+	_, err = DataSourceListAttributeFromResourceSchema(resourceSchema, "Test list.")
+	if err == nil {
+		t.Fatal("expected an error instead of a panic deriving the unsupported attribute")
+	}
+}
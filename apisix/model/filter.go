@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ListIDSchemaAttribute is the placeholder identifier of the list data sources.
+var ListIDSchemaAttribute = schema.StringAttribute{
+	Description: "Placeholder identifier attribute.",
+	Computed:    true,
+}
+
+// LabelsFilterSchemaAttribute filters objects by labels in the list data sources.
+var LabelsFilterSchemaAttribute = schema.MapAttribute{
+	MarkdownDescription: "Only return objects which have all of the specified labels, e.g. `{ team = \"payments\" }`.",
+	ElementType:         types.StringType,
+	Optional:            true,
+}
+
+// NamePrefixFilterSchemaAttribute filters objects by the name prefix in the list data sources.
+var NamePrefixFilterSchemaAttribute = schema.StringAttribute{
+	MarkdownDescription: "Only return objects whose `name` starts with the specified prefix.",
+	Optional:            true,
+}
+
+func labelSelector(ctx context.Context, labels types.Map) (selector map[string]string, diags diag.Diagnostics) {
+	if labels.IsNull() || labels.IsUnknown() {
+		return nil, nil
+	}
+
+	diags = labels.ElementsAs(ctx, &selector, false)
+
+	return selector, diags
+}
+
+func matchesLabels(labels *map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels == nil {
+			return false
+		}
+
+		if actual, ok := (*labels)[key]; !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesPrefix(value *string, prefix types.String) bool {
+	if prefix.IsNull() || prefix.IsUnknown() {
+		return true
+	}
+
+	return value != nil && strings.HasPrefix(*value, prefix.ValueString())
+}
+
+// matchesAnyValue reports whether the filter matches the value or one of the values, using
+// match to compare them with the filter.
+func matchesAnyValue(value *string, values *[]string, filter types.String, match func(pattern, value string) bool) bool {
+	if filter.IsNull() || filter.IsUnknown() {
+		return true
+	}
+
+	if value != nil && match(*value, filter.ValueString()) {
+		return true
+	}
+
+	if values != nil {
+		for _, v := range *values {
+			if match(v, filter.ValueString()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesHost matches the host the way APISIX does, so the wildcard host `*.example.com`
+// matches any of its subdomains, e.g. `api.example.com`.
+func matchesHost(pattern, host string) bool {
+	if pattern == host {
+		return true
+	}
+
+	return strings.HasPrefix(pattern, "*") && strings.HasSuffix(host, strings.TrimPrefix(pattern, "*"))
+}
+
+// matchesURI matches the URI the way APISIX does, so the URI `/api/*` matches any URI
+// starting with `/api/`.
+func matchesURI(pattern, uri string) bool {
+	if pattern == uri {
+		return true
+	}
+
+	return strings.HasSuffix(pattern, "*") && strings.HasPrefix(uri, strings.TrimSuffix(pattern, "*"))
+}
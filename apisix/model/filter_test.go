@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatchesAnyValueWildcards(t *testing.T) {
+	host := "*.example.com"
+	uris := []string{"/health", "/api/*"}
+
+	testCases := map[string]struct {
+		value  *string
+		values *[]string
+		filter types.String
+		match  func(pattern, value string) bool
+		want   bool
+	}{
+		"no filter":          {value: nil, filter: types.StringNull(), match: matchesHost, want: true},
+		"wildcard host":      {value: &host, filter: types.StringValue("api.example.com"), match: matchesHost, want: true},
+		"nested subdomain":   {value: &host, filter: types.StringValue("v1.api.example.com"), match: matchesHost, want: true},
+		"same wildcard host": {value: &host, filter: types.StringValue("*.example.com"), match: matchesHost, want: true},
+		"other domain":       {value: &host, filter: types.StringValue("example.com"), match: matchesHost, want: false},
+		"exact uri":          {values: &uris, filter: types.StringValue("/health"), match: matchesURI, want: true},
+		"prefix uri":         {values: &uris, filter: types.StringValue("/api/users"), match: matchesURI, want: true},
+		"same prefix uri":    {values: &uris, filter: types.StringValue("/api/*"), match: matchesURI, want: true},
+		"not exact uri":      {values: &uris, filter: types.StringValue("/health/live"), match: matchesURI, want: false},
+		"other uri":          {values: &uris, filter: types.StringValue("/admin"), match: matchesURI, want: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := matchesAnyValue(testCase.value, testCase.values, testCase.filter, testCase.match); got != testCase.want {
+				t.Fatalf("expected %t, got %t", testCase.want, got)
+			}
+		})
+	}
+}
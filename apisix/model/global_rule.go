@@ -5,17 +5,24 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // GlobalRuleResourceModel maps the resource schema data.
 type GlobalRuleResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Plugins types.String `tfsdk:"plugins"`
+	ID           types.String   `tfsdk:"id"`
+	Plugins      PluginsValue   `tfsdk:"plugins"`
+	TypedPlugins types.Object   `tfsdk:"typed_plugins"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 var GlobalRuleSchema = schema.Schema{
@@ -29,30 +36,49 @@ var GlobalRuleSchema = schema.Schema{
 			},
 		},
 		"plugins": schema.StringAttribute{
+			CustomType:  PluginsType{},
 			Description: "Plugins that are executed during the request/response cycle.",
-			Required:    true,
+			Optional:    true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
 		},
+		"typed_plugins": TypedPluginsSchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
 	},
 }
 
-func GlobalRuleFromTerraformToApi(ctx context.Context, terraformDataModel *GlobalRuleResourceModel) (apiDataModel api_client.GlobalRule) {
+// GlobalRuleDataSourceSchema returns the schema of the global rule data source.
+func GlobalRuleDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(GlobalRuleSchema, "id", "Retrieves an APISIX global rule by its identifier.")
+}
+
+func GlobalRuleFromTerraformToApi(ctx context.Context, terraformDataModel *GlobalRuleResourceModel) (apiDataModel api_client.GlobalRule, diags diag.Diagnostics) {
 	apiDataModel.ID = terraformDataModel.ID.ValueStringPointer()
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	plugins, pluginsDiags = TypedPluginsMerge(ctx, plugins, terraformDataModel.TypedPlugins)
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
 
 	tflog.Debug(ctx, "Result of the GlobalRuleFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func GlobalRuleFromApiToTerraform(ctx context.Context, apiDataModel *api_client.GlobalRule) (terraformDataModel GlobalRuleResourceModel) {
+func GlobalRuleFromApiToTerraform(ctx context.Context, apiDataModel *api_client.GlobalRule) (terraformDataModel GlobalRuleResourceModel, diags diag.Diagnostics) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
-	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.Plugins, diags = PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	terraformDataModel.TypedPlugins = NewTypedPluginsNull()
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of the GlobalRuleFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
 }
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	// objectIDRegex matches the identifiers of the APISIX objects.
+	objectIDRegex = regexp.MustCompile(`^[a-zA-Z0-9-_.]+$`)
+	// consumerUsernameRegex matches the usernames of the APISIX consumers.
+	consumerUsernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// ObjectIDValidators validate the identifier of the APISIX object.
+func ObjectIDValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthBetween(1, 64),
+		stringvalidator.RegexMatches(objectIDRegex, "must contain only alphanumeric characters, `-`, `_` and `.`"),
+	}
+}
+
+// ValidateObjectID checks the identifier of the APISIX object, such as the import identifier.
+func ValidateObjectID(id string) error {
+	if len(id) < 1 || len(id) > 64 || !objectIDRegex.MatchString(id) {
+		return fmt.Errorf("expected the identifier of 1 to 64 alphanumeric characters, `-`, `_` and `.`, got: %q", id)
+	}
+
+	return nil
+}
+
+// ValidateConsumerUsername checks the username of the APISIX consumer.
+func ValidateConsumerUsername(username string) error {
+	if len(username) < 1 || len(username) > 100 || !consumerUsernameRegex.MatchString(username) {
+		return fmt.Errorf("expected the username of 1 to 100 alphanumeric characters, `-` and `_`, got: %q", username)
+	}
+
+	return nil
+}
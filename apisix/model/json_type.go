@@ -0,0 +1,385 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = JSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONValue{}
+	_ basetypes.StringTypable                    = PluginsType{}
+	_ basetypes.StringValuableWithSemanticEquals = PluginsValue{}
+)
+
+type jsonComparison int
+
+const (
+	// jsonExact requires both documents to be equal
+	jsonExact jsonComparison = iota
+	// jsonPluginDefaults allows the fields set by APISIX with their default values in the configuration of each plugin
+	jsonPluginDefaults
+)
+
+// JSONType is a string type for attributes holding a JSON document, e.g. the route vars.
+// Values which differ only in key ordering or whitespace are semantically equal.
+type JSONType struct {
+	basetypes.StringType
+}
+
+func (t JSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONType) String() string {
+	return "model.JSONType"
+}
+
+func (t JSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONValue{StringValue: in}, nil
+}
+
+func (t JSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return JSONValue{StringValue: stringValue}, nil
+}
+
+func (t JSONType) ValueType(_ context.Context) attr.Value {
+	return JSONValue{}
+}
+
+// JSONValue is the value of the JSONType.
+type JSONValue struct {
+	basetypes.StringValue
+}
+
+func NewJSONNull() JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewJSONValue(value string) JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v JSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v JSONValue) Type(_ context.Context) attr.Type {
+	return JSONType{}
+}
+
+// StringSemanticEquals returns true if both values decode to the same JSON document.
+func (v JSONValue) StringSemanticEquals(_ context.Context, prior basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	priorValue, ok := prior.(JSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, prior),
+		)
+		return false, diags
+	}
+
+	return jsonEqual(v.ValueString(), priorValue.ValueString(), jsonExact), diags
+}
+
+// PluginsType is a string type for the plugins attribute.
+// Besides the key ordering and whitespace, it ignores the plugin configuration fields
+// which are missing in the configuration but returned by APISIX with their default values,
+// as long as the plugin schema, or the fallbackPluginDefaults table, declares the default value.
+// Adding or removing a plugin or a field, or changing any field is still detected as a difference.
+type PluginsType struct {
+	basetypes.StringType
+}
+
+func (t PluginsType) Equal(o attr.Type) bool {
+	other, ok := o.(PluginsType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t PluginsType) String() string {
+	return "model.PluginsType"
+}
+
+func (t PluginsType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return PluginsValue{StringValue: in}, nil
+}
+
+func (t PluginsType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return PluginsValue{StringValue: stringValue}, nil
+}
+
+func (t PluginsType) ValueType(_ context.Context) attr.Value {
+	return PluginsValue{}
+}
+
+// PluginsValue is the value of the PluginsType.
+type PluginsValue struct {
+	basetypes.StringValue
+}
+
+func NewPluginsNull() PluginsValue {
+	return PluginsValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewPluginsValue(value string) PluginsValue {
+	return PluginsValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v PluginsValue) Equal(o attr.Value) bool {
+	other, ok := o.(PluginsValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v PluginsValue) Type(_ context.Context) attr.Type {
+	return PluginsType{}
+}
+
+// StringSemanticEquals returns true if the plugins returned by APISIX match the configured ones.
+func (v PluginsValue) StringSemanticEquals(_ context.Context, prior basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	priorValue, ok := prior.(PluginsValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, prior),
+		)
+		return false, diags
+	}
+
+	return jsonEqual(v.ValueString(), priorValue.ValueString(), jsonPluginDefaults), diags
+}
+
+// pluginSchemaDefaults holds the default values of the plugin fields declared in the plugin schemas
+// fetched from APISIX, by the plugin name. Only these fields are ignored when they are missing
+// in the configured plugins, so any other field added outside of Terraform is detected as a drift.
+var pluginSchemaDefaults = struct {
+	sync.RWMutex
+	plugins map[string]*admin.PluginDefaults
+}{
+	plugins: map[string]*admin.PluginDefaults{},
+}
+
+// RegisterPluginDefaults adds the default values of the plugin fields declared in the plugin schema,
+// so the fields set by APISIX are not reported as a difference from the configured plugins.
+func RegisterPluginDefaults(name string, defaults *admin.PluginDefaults) {
+	if defaults == nil {
+		return
+	}
+
+	pluginSchemaDefaults.Lock()
+	defer pluginSchemaDefaults.Unlock()
+
+	registered, ok := pluginSchemaDefaults.plugins[name]
+	if !ok {
+		registered = admin.NewPluginDefaults()
+		pluginSchemaDefaults.plugins[name] = registered
+	}
+	registered.Merge(defaults)
+}
+
+// pluginDefaultsOf returns the registered defaults of the plugin, or the fallback ones
+// when the plugin schema can't be fetched, e.g. in the standalone mode.
+func pluginDefaultsOf(name string) *admin.PluginDefaults {
+	pluginSchemaDefaults.RLock()
+	defaults, ok := pluginSchemaDefaults.plugins[name]
+	pluginSchemaDefaults.RUnlock()
+	if ok {
+		return defaults
+	}
+
+	fallback, ok := fallbackPluginDefaults[name]
+	if !ok {
+		return nil
+	}
+
+	defaults = admin.NewPluginDefaults()
+	for field, value := range fallback {
+		defaults.Values[field] = []interface{}{value}
+	}
+
+	return defaults
+}
+
+// fallbackPluginDefaults lists the default values of the common plugins,
+// used unless the plugin schema is fetched from APISIX.
+var fallbackPluginDefaults = map[string]map[string]interface{}{
+	"basic-auth": {
+		"hide_credentials": false,
+	},
+	"cors": {
+		"allow_credential": false,
+		"allow_headers":    "*",
+		"allow_methods":    "*",
+		"allow_origins":    "*",
+		"expose_headers":   "*",
+		"max_age":          float64(5),
+	},
+	"ip-restriction": {
+		"message": "Your IP address is not allowed",
+	},
+	"jwt-auth": {
+		"cookie":           "jwt",
+		"header":           "authorization",
+		"hide_credentials": false,
+		"query":            "jwt",
+	},
+	"key-auth": {
+		"header":           "apikey",
+		"hide_credentials": false,
+		"query":            "apikey",
+	},
+	"limit-conn": {
+		"allow_degradation":      false,
+		"key_type":               "var",
+		"only_use_default_delay": false,
+		"rejected_code":          float64(503),
+	},
+	"limit-count": {
+		"allow_degradation":       false,
+		"key":                     "remote_addr",
+		"key_type":                "var",
+		"policy":                  "local",
+		"rejected_code":           float64(503),
+		"show_limit_quota_header": true,
+	},
+	"limit-req": {
+		"allow_degradation": false,
+		"key_type":          "var",
+		"nodelay":           false,
+		"policy":            "local",
+		"rejected_code":     float64(503),
+	},
+	"prometheus": {
+		"prefer_name": false,
+	},
+	"proxy-rewrite": {
+		"use_real_request_uri_unsafe": false,
+	},
+}
+
+// jsonEqual compares two JSON documents using the given comparison.
+func jsonEqual(current string, prior string, comparison jsonComparison) bool {
+	var currentJSON, priorJSON interface{}
+
+	if err := json.Unmarshal([]byte(current), &currentJSON); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(prior), &priorJSON); err != nil {
+		return false
+	}
+
+	if comparison == jsonPluginDefaults {
+		currentPlugins, ok := currentJSON.(map[string]interface{})
+		if !ok {
+			return reflect.DeepEqual(currentJSON, priorJSON)
+		}
+		priorPlugins, ok := priorJSON.(map[string]interface{})
+		if !ok || len(currentPlugins) != len(priorPlugins) {
+			return false
+		}
+
+		for name, priorConfig := range priorPlugins {
+			currentConfig, ok := currentPlugins[name]
+			if !ok || !pluginConfigEqual(currentConfig, priorConfig, pluginDefaultsOf(name)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(currentJSON, priorJSON)
+}
+
+// pluginConfigEqual reports whether the current plugin configuration equals the prior one,
+// allowing the current configuration to contain the fields set by APISIX with their default values.
+// The nested objects are compared the same way, using the defaults of their fields.
+func pluginConfigEqual(current interface{}, prior interface{}, defaults *admin.PluginDefaults) bool {
+	currentObject, ok := current.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(current, prior)
+	}
+	priorObject, ok := prior.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	for key, currentField := range currentObject {
+		priorField, ok := priorObject[key]
+		if !ok {
+			if !defaults.HasDefault(key, currentField) {
+				return false
+			}
+			continue
+		}
+
+		var fieldDefaults *admin.PluginDefaults
+		if defaults != nil {
+			fieldDefaults = defaults.Properties[key]
+		}
+
+		if !pluginConfigEqual(currentField, priorField, fieldDefaults) {
+			return false
+		}
+	}
+
+	for key := range priorObject {
+		if _, ok := currentObject[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
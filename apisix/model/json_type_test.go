@@ -0,0 +1,219 @@
+package model
+
+import (
+	"testing"
+
+	"terraform-provider-apisix/apisix/admin"
+)
+
+func TestJSONEqual(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    string
+		prior      string
+		comparison jsonComparison
+		expected   bool
+	}{
+		{
+			name:       "key order",
+			current:    `{"b": 2, "a": 1}`,
+			prior:      `{"a": 1, "b": 2}`,
+			comparison: jsonExact,
+			expected:   true,
+		},
+		{
+			name:       "whitespace",
+			current:    `{"a":[1,2]}`,
+			prior:      "{\n\t\"a\": [ 1, 2 ]\n}",
+			comparison: jsonExact,
+			expected:   true,
+		},
+		{
+			name:       "added key",
+			current:    `{"a": 1, "b": 2}`,
+			prior:      `{"a": 1}`,
+			comparison: jsonExact,
+			expected:   false,
+		},
+		{
+			name:       "removed key",
+			current:    `{"a": 1}`,
+			prior:      `{"a": 1, "b": 2}`,
+			comparison: jsonExact,
+			expected:   false,
+		},
+		{
+			name:       "changed array element",
+			current:    `[["uri", "==", "/a"]]`,
+			prior:      `[["uri", "==", "/b"]]`,
+			comparison: jsonExact,
+			expected:   false,
+		},
+		{
+			name:       "invalid JSON",
+			current:    `{"a": 1`,
+			prior:      `{"a": 1}`,
+			comparison: jsonExact,
+			expected:   false,
+		},
+		{
+			name:       "plugin defaults set by APISIX",
+			current:    `{"limit-count": {"count": 10, "time_window": 60, "rejected_code": 503, "key": "remote_addr"}, "prometheus": {"prefer_name": false}}`,
+			prior:      `{"prometheus": {}, "limit-count": {"time_window": 60, "count": 10}}`,
+			comparison: jsonPluginDefaults,
+			expected:   true,
+		},
+		{
+			name:       "plugin default field with another value",
+			current:    `{"limit-count": {"count": 10, "time_window": 60, "rejected_code": 429}}`,
+			prior:      `{"limit-count": {"count": 10, "time_window": 60}}`,
+			comparison: jsonPluginDefaults,
+			expected:   false,
+		},
+		{
+			name:       "plugin field added outside of the defaults",
+			current:    `{"limit-count": {"count": 10, "time_window": 60, "group": "shared"}}`,
+			prior:      `{"limit-count": {"count": 10, "time_window": 60}}`,
+			comparison: jsonPluginDefaults,
+			expected:   false,
+		},
+		{
+			name:       "plugin field removed",
+			current:    `{"limit-count": {"count": 10}}`,
+			prior:      `{"limit-count": {"count": 10, "time_window": 60}}`,
+			comparison: jsonPluginDefaults,
+			expected:   false,
+		},
+		{
+			name:       "plugin added",
+			current:    `{"limit-count": {"count": 10, "time_window": 60}, "prometheus": {}}`,
+			prior:      `{"limit-count": {"count": 10, "time_window": 60}}`,
+			comparison: jsonPluginDefaults,
+			expected:   false,
+		},
+		{
+			name:       "nested plugin field added",
+			current:    `{"proxy-rewrite": {"headers": {"set": {"X-Api": "1", "X-Added": "1"}}}}`,
+			prior:      `{"proxy-rewrite": {"headers": {"set": {"X-Api": "1"}}}}`,
+			comparison: jsonPluginDefaults,
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := jsonEqual(test.current, test.prior, test.comparison)
+			if actual != test.expected {
+				t.Errorf("expected %t, got %t comparing %s with %s", test.expected, actual, test.current, test.prior)
+			}
+		})
+	}
+}
+
+func TestJSONEqualPluginSchemaDefaults(t *testing.T) {
+	schemas := map[string]string{
+		// Not in the fallback table
+		"request-id": `{
+			"type": "object",
+			"properties": {
+				"header_name": {"type": "string", "default": "X-Request-Id"},
+				"include_in_response": {"type": "boolean", "default": true},
+				"algorithm": {"type": "string", "enum": ["uuid", "nanoid", "range_id"], "default": "uuid"},
+				"range_id": {
+					"type": "object",
+					"properties": {
+						"length": {"type": "integer", "default": 16}
+					}
+				}
+			}
+		}`,
+		// The redis fields have the defaults only in the conditional subschema
+		"limit-count": `{
+			"type": "object",
+			"properties": {
+				"count": {"type": "integer"},
+				"time_window": {"type": "integer"},
+				"policy": {"type": "string", "enum": ["local", "redis"], "default": "local"}
+			},
+			"if": {"properties": {"policy": {"enum": ["redis"]}}},
+			"then": {
+				"properties": {
+					"redis_host": {"type": "string"},
+					"redis_port": {"type": "integer", "default": 6379},
+					"redis_timeout": {"type": "integer", "default": 1000}
+				}
+			}
+		}`,
+	}
+
+	for name, schema := range schemas {
+		defaults, err := admin.ParsePluginDefaults([]byte(schema))
+		if err != nil {
+			t.Fatalf("unexpected error parsing the %s schema: %s", name, err)
+		}
+
+		registerTestPluginDefaults(t, name, defaults)
+	}
+
+	tests := []struct {
+		name     string
+		current  string
+		prior    string
+		expected bool
+	}{
+		{
+			name:     "schema defaults set by APISIX",
+			current:  `{"request-id": {"header_name": "X-Request-Id", "include_in_response": true, "algorithm": "uuid"}}`,
+			prior:    `{"request-id": {}}`,
+			expected: true,
+		},
+		{
+			name:     "schema default field with another value",
+			current:  `{"request-id": {"header_name": "X-Trace-Id"}}`,
+			prior:    `{"request-id": {}}`,
+			expected: false,
+		},
+		{
+			name:     "nested schema defaults set by APISIX",
+			current:  `{"request-id": {"algorithm": "range_id", "range_id": {"length": 16, "char_set": "abc"}}}`,
+			prior:    `{"request-id": {"algorithm": "range_id", "range_id": {"char_set": "abc"}}}`,
+			expected: true,
+		},
+		{
+			name:     "conditional schema defaults set by APISIX",
+			current:  `{"limit-count": {"count": 10, "time_window": 60, "policy": "redis", "redis_host": "redis", "redis_port": 6379, "redis_timeout": 1000}}`,
+			prior:    `{"limit-count": {"count": 10, "time_window": 60, "policy": "redis", "redis_host": "redis"}}`,
+			expected: true,
+		},
+		{
+			name:     "field outside of the schema defaults",
+			current:  `{"limit-count": {"count": 10, "time_window": 60, "rejected_code": 503}}`,
+			prior:    `{"limit-count": {"count": 10, "time_window": 60}}`,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := jsonEqual(test.current, test.prior, jsonPluginDefaults)
+			if actual != test.expected {
+				t.Errorf("expected %t, got %t comparing %s with %s", test.expected, actual, test.current, test.prior)
+			}
+		})
+	}
+}
+
+// registerTestPluginDefaults registers the plugin defaults for the test only,
+// so the fallback defaults are used by the other tests.
+func registerTestPluginDefaults(t *testing.T, name string, defaults *admin.PluginDefaults) {
+	t.Helper()
+
+	RegisterPluginDefaults(name, defaults)
+
+	t.Cleanup(func() {
+		pluginSchemaDefaults.Lock()
+		defer pluginSchemaDefaults.Unlock()
+
+		delete(pluginSchemaDefaults.plugins, name)
+	})
+}
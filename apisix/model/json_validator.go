@@ -0,0 +1,58 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = jsonValidator{}
+
+// jsonValidator validates that a string attribute contains a JSON document of the expected kind.
+type jsonValidator struct {
+	kind   string
+	target func() any
+}
+
+func (v jsonValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a valid JSON %s", v.kind)
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), v.target())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON String",
+			fmt.Sprintf("Attribute %s %s, got error: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// IsJSONObject returns a validator which ensures that the string is a JSON object,
+// e.g. the plugins configuration.
+func IsJSONObject() validator.String {
+	return jsonValidator{
+		kind:   "object",
+		target: func() any { return &map[string]interface{}{} },
+	}
+}
+
+// IsJSONArray returns a validator which ensures that the string is a JSON array,
+// e.g. the route vars.
+func IsJSONArray() validator.String {
+	return jsonValidator{
+		kind:   "array",
+		target: func() any { return &[]interface{}{} },
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = pemCertificatesValidator{}
+
+// pemCertificatesValidator validates that a string attribute contains PEM encoded certificates only.
+type pemCertificatesValidator struct{}
+
+func (v pemCertificatesValidator) Description(_ context.Context) string {
+	return "value must contain one or more PEM encoded certificates"
+}
+
+func (v pemCertificatesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pemCertificatesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := ParsePEMCertificates(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid PEM Certificates",
+			fmt.Sprintf("Attribute %s %s, got error: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// IsPEMCertificates returns a validator which ensures that the string is a bundle of PEM encoded certificates,
+// e.g. the CA verifying the client certificates.
+func IsPEMCertificates() validator.String {
+	return pemCertificatesValidator{}
+}
+
+// ParsePEMCertificates parses the certificates of the PEM bundle in their order.
+func ParsePEMCertificates(bundle string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected %s PEM block %d", block.Type, len(certificates)+1)
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", len(certificates)+1, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, fmt.Errorf("unexpected data after the PEM certificate %d", len(certificates))
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+
+	return certificates, nil
+}
@@ -5,19 +5,26 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // PluginConfigResourceModel maps the resource schema data.
 type PluginConfigResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Description types.String `tfsdk:"desc"`
-	Labels      types.Map    `tfsdk:"labels"`
-	Plugins     types.String `tfsdk:"plugins"`
+	ID           types.String   `tfsdk:"id"`
+	Description  types.String   `tfsdk:"desc"`
+	Labels       types.Map      `tfsdk:"labels"`
+	Plugins      PluginsValue   `tfsdk:"plugins"`
+	TypedPlugins types.Object   `tfsdk:"typed_plugins"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 var PluginConfigSchema = schema.Schema{
@@ -40,34 +47,53 @@ var PluginConfigSchema = schema.Schema{
 			Optional:    true,
 		},
 		"plugins": schema.StringAttribute{
+			CustomType:  PluginsType{},
 			Description: "Plugins that are executed during the request/response cycle.",
-			Required:    true,
+			Optional:    true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
 		},
+		"typed_plugins": TypedPluginsSchemaAttribute,
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// PluginConfigDataSourceSchema returns the schema of the plugin config data source.
+func PluginConfigDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(PluginConfigSchema, "id", "Retrieves an APISIX plugin config by its identifier.")
 }
 
-func PluginConfigFromTerraformToApi(ctx context.Context, terraformDataModel *PluginConfigResourceModel) (apiDataModel api_client.PluginConfig) {
+func PluginConfigFromTerraformToApi(ctx context.Context, terraformDataModel *PluginConfigResourceModel) (apiDataModel api_client.PluginConfig, diags diag.Diagnostics) {
 	apiDataModel.ID = terraformDataModel.ID.ValueStringPointer()
 	apiDataModel.Description = terraformDataModel.Description.ValueStringPointer()
 	terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	plugins, pluginsDiags = TypedPluginsMerge(ctx, plugins, terraformDataModel.TypedPlugins)
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
 
 	tflog.Debug(ctx, "Result of the PluginConfigFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func PluginConfigFromApiToTerraform(ctx context.Context, apiDataModel *api_client.PluginConfig) (terraformDataModel PluginConfigResourceModel) {
+func PluginConfigFromApiToTerraform(ctx context.Context, apiDataModel *api_client.PluginConfig) (terraformDataModel PluginConfigResourceModel, diags diag.Diagnostics) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
 	terraformDataModel.Description = types.StringPointerValue(apiDataModel.Description)
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
-	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.Plugins, diags = PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	terraformDataModel.TypedPlugins = NewTypedPluginsNull()
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of the PluginConfigFromApiToTerraform", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
 }
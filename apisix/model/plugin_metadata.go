@@ -0,0 +1,107 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// PluginMetadataResourceModel maps the resource schema data.
+type PluginMetadataResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	PluginName types.String   `tfsdk:"plugin_name"`
+	Metadata   JSONValue      `tfsdk:"metadata"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+var PluginMetadataSchema = schema.Schema{
+	Description: "Manages APISIX Plugin Metadata, the configuration shared by all instances of the plugin.",
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Identifier of the plugin metadata. Equal to the plugin name.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"plugin_name": schema.StringAttribute{
+			MarkdownDescription: "Name of the plugin, e.g. `http-logger`.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"metadata": schema.StringAttribute{
+			CustomType:          JSONType{},
+			MarkdownDescription: "Metadata of the plugin, e.g. the `log_format` of the logger plugins.",
+			Required:            true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
+		},
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+func PluginMetadataFromTerraformToApi(ctx context.Context, terraformDataModel *PluginMetadataResourceModel) (apiDataModel admin.PluginMetadata, diags diag.Diagnostics) {
+	err := json.Unmarshal([]byte(terraformDataModel.Metadata.ValueString()), &apiDataModel)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("metadata"),
+			"Error Converting Plugin Metadata",
+			"Could not convert plugin metadata to JSON object: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	tflog.Debug(ctx, "Result of the PluginMetadataFromTerraformToApi", map[string]any{
+		"Values": apiDataModel,
+	})
+
+	return apiDataModel, diags
+}
+
+func PluginMetadataFromApiToTerraform(ctx context.Context, pluginName string, apiDataModel *admin.PluginMetadata) (terraformDataModel PluginMetadataResourceModel, diags diag.Diagnostics) {
+	// APISIX adds the plugin name as the id and the timestamps to the metadata
+	metadata := admin.PluginMetadata{}
+	for key, value := range *apiDataModel {
+		switch key {
+		case "id", "create_time", "update_time":
+			continue
+		}
+		metadata[key] = value
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		diags.AddError(
+			"Error Converting Plugin Metadata",
+			"Could not convert plugin metadata to string: "+err.Error(),
+		)
+		return terraformDataModel, diags
+	}
+
+	terraformDataModel.ID = types.StringValue(pluginName)
+	terraformDataModel.PluginName = types.StringValue(pluginName)
+	terraformDataModel.Metadata = NewJSONValue(string(data))
+	terraformDataModel.Timeouts = nullTimeouts()
+
+	tflog.Debug(ctx, "Result of the PluginMetadataFromApiToTerraform", map[string]any{
+		"Values": terraformDataModel,
+	})
+
+	return terraformDataModel, diags
+}
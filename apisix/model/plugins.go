@@ -0,0 +1,74 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginsDataSourceModel maps the plugins data source schema data.
+type PluginsDataSourceModel struct {
+	ID      types.String            `tfsdk:"id"`
+	Type    types.String            `tfsdk:"type"`
+	Names   types.List              `tfsdk:"names"`
+	Plugins []PluginDataSourceModel `tfsdk:"plugins"`
+}
+
+// PluginDataSourceModel maps the plugin enabled in APISIX.
+type PluginDataSourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Type     types.String `tfsdk:"type"`
+	Schema   JSONValue    `tfsdk:"schema"`
+}
+
+var PluginsDataSourceSchema = schema.Schema{
+	MarkdownDescription: "Lists the plugins enabled in APISIX along with their JSON schemas, " +
+		"so the modules can check the plugins they require are enabled in the `config.yaml` of the gateway.",
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Identifier of the data source, the `type` filter or `all` when the plugins of both subsystems are listed.",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Only return the plugins of the subsystem, either `http` or `stream`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("http", "stream"),
+			},
+		},
+		"names": schema.ListAttribute{
+			MarkdownDescription: "Sorted names of the enabled plugins, such as `limit-count`, listed once even if the plugin is available in both subsystems.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"plugins": schema.ListNestedAttribute{
+			MarkdownDescription: "List of the enabled plugins ordered by the type and name.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the plugin.",
+						Computed:            true,
+					},
+					"priority": schema.Int64Attribute{
+						MarkdownDescription: "Priority of the plugin. The plugins with the higher priority are executed first. " +
+							"Null when APISIX doesn't return the plugin priorities.",
+						Computed: true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Subsystem of the plugin, either `http` or `stream`. " +
+							"The plugins available in both subsystems, such as `ip-restriction`, are listed once per subsystem.",
+						Computed: true,
+					},
+					"schema": schema.StringAttribute{
+						CustomType:          JSONType{},
+						MarkdownDescription: "JSON schema of the plugin configuration.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	},
+}
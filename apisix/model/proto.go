@@ -0,0 +1,130 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ProtoResourceModel maps the resource schema data.
+type ProtoResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Desc          types.String   `tfsdk:"desc"`
+	Content       types.String   `tfsdk:"content"`
+	ContentFile   types.String   `tfsdk:"content_file"`
+	ContentSHA256 types.String   `tfsdk:"content_sha256"`
+	Labels        types.Map      `tfsdk:"labels"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+var ProtoSchema = schema.Schema{
+	MarkdownDescription: "Manages APISIX Protos, the protobuf definitions used by the `grpc-transcode` plugin.",
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Identifier of the proto. Used as the `proto_id` in the `grpc-transcode` plugin configuration. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: ObjectIDValidators(),
+		},
+		"desc": schema.StringAttribute{
+			MarkdownDescription: "Description of usage scenarios.",
+			Optional:            true,
+		},
+		"content": schema.StringAttribute{
+			MarkdownDescription: "Content of the `.proto` file. Conflicts with `content_file`.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"content_file": schema.StringAttribute{
+			MarkdownDescription: "Path to the `.proto` file. Its changes are detected during the plan by `content_sha256`, and the file is uploaded during the apply. Conflicts with `content`.",
+			Optional:            true,
+		},
+		"content_sha256": schema.StringAttribute{
+			MarkdownDescription: "SHA-256 checksum of the `content`, hex encoded. Unknown until the apply when the `content_file` changed.",
+			Computed:            true,
+		},
+		"labels": schema.MapAttribute{
+			MarkdownDescription: "Attributes of the Proto specified as `key-value` pairs.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// ProtoContentFromFile reads the content of the proto file.
+func ProtoContentFromFile(ctx context.Context, contentFile types.String) (content types.String, diags diag.Diagnostics) {
+	data, err := os.ReadFile(contentFile.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "Error reading the proto file", map[string]any{
+			"Error": err,
+			"Path":  contentFile.ValueString(),
+		})
+		diags.AddAttributeError(
+			path.Root("content_file"),
+			"Error Reading Proto File",
+			"Could not read the proto file "+contentFile.ValueString()+": "+err.Error(),
+		)
+		return types.StringUnknown(), diags
+	}
+
+	return types.StringValue(string(data)), diags
+}
+
+// ProtoContentSHA256 returns the hex encoded SHA-256 checksum of the proto content.
+func ProtoContentSHA256(content string) string {
+	checksum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(checksum[:])
+}
+
+func ProtoFromTerraformToApi(ctx context.Context, terraformDataModel *ProtoResourceModel) (apiDataModel admin.Proto, diags diag.Diagnostics) {
+	apiDataModel.Desc = terraformDataModel.Desc.ValueStringPointer()
+	apiDataModel.Content = terraformDataModel.Content.ValueStringPointer()
+
+	diags = terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, false)
+
+	tflog.Debug(ctx, "Result of the ProtoFromTerraformToApi", map[string]any{
+		"Values": apiDataModel,
+	})
+
+	return apiDataModel, diags
+}
+
+func ProtoFromApiToTerraform(ctx context.Context, apiDataModel *admin.Proto, contentFile types.String) (terraformDataModel ProtoResourceModel, diags diag.Diagnostics) {
+	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
+	terraformDataModel.Desc = types.StringPointerValue(apiDataModel.Desc)
+	terraformDataModel.Content = types.StringPointerValue(apiDataModel.Content)
+	terraformDataModel.ContentFile = contentFile
+	terraformDataModel.ContentSHA256 = types.StringNull()
+	if apiDataModel.Content != nil {
+		terraformDataModel.ContentSHA256 = types.StringValue(ProtoContentSHA256(*apiDataModel.Content))
+	}
+
+	terraformDataModel.Labels, diags = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
+	terraformDataModel.Timeouts = nullTimeouts()
+
+	tflog.Debug(ctx, "Result of the ProtoFromApiToTerraform", map[string]any{
+		"Values": terraformDataModel,
+	})
+
+	return terraformDataModel, diags
+}
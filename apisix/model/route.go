@@ -3,11 +3,15 @@ package model
 import (
 	"context"
 
-	"github.com/holubovskyi/apisix-client-go"
+	"terraform-provider-apisix/apisix/admin"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
@@ -20,39 +24,45 @@ import (
 
 // RouteResourceModel maps the resource schema data.
 type RouteResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"desc"`
-	URI             types.String `tfsdk:"uri"`
-	URIS            types.List   `tfsdk:"uris"`
-	Host            types.String `tfsdk:"host"`
-	Hosts           types.List   `tfsdk:"hosts"`
-	RemoteAddr      types.String `tfsdk:"remote_addr"`
-	RemoteAddrs     types.List   `tfsdk:"remote_addrs"`
-	Methods         types.List   `tfsdk:"methods"`
-	Priority        types.Int64  `tfsdk:"priority"`
-	Vars            types.String `tfsdk:"vars"`
-	FilterFunc      types.String `tfsdk:"filter_func"`
-	Plugins         types.String `tfsdk:"plugins"`
-	Script          types.String `tfsdk:"script"`
-	UpstreamId      types.String `tfsdk:"upstream_id"`
-	ServiceId       types.String `tfsdk:"service_id"`
-	PluginConfigId  types.String `tfsdk:"plugin_config_id"`
-	Labels          types.Map    `tfsdk:"labels"`
-	Timeout         *TimeoutType `tfsdk:"timeout"`
-	EnableWebsocket types.Bool   `tfsdk:"enable_websocket"`
-	Status          types.Int64  `tfsdk:"status"`
+	ID              types.String        `tfsdk:"id"`
+	Name            types.String        `tfsdk:"name"`
+	Description     types.String        `tfsdk:"desc"`
+	URI             types.String        `tfsdk:"uri"`
+	URIS            types.List          `tfsdk:"uris"`
+	Host            types.String        `tfsdk:"host"`
+	Hosts           types.List          `tfsdk:"hosts"`
+	RemoteAddr      types.String        `tfsdk:"remote_addr"`
+	RemoteAddrs     types.List          `tfsdk:"remote_addrs"`
+	Methods         types.List          `tfsdk:"methods"`
+	Priority        types.Int64         `tfsdk:"priority"`
+	Vars            JSONValue           `tfsdk:"vars"`
+	FilterFunc      types.String        `tfsdk:"filter_func"`
+	Plugins         PluginsValue        `tfsdk:"plugins"`
+	TypedPlugins    types.Object        `tfsdk:"typed_plugins"`
+	Script          types.String        `tfsdk:"script"`
+	UpstreamId      types.String        `tfsdk:"upstream_id"`
+	Upstream        *UpstreamInlineType `tfsdk:"upstream"`
+	ServiceId       types.String        `tfsdk:"service_id"`
+	PluginConfigId  types.String        `tfsdk:"plugin_config_id"`
+	Labels          types.Map           `tfsdk:"labels"`
+	Timeout         *TimeoutType        `tfsdk:"timeout"`
+	EnableWebsocket types.Bool          `tfsdk:"enable_websocket"`
+	Status          types.Int64         `tfsdk:"status"`
+	Timeouts        timeouts.Value      `tfsdk:"timeouts"`
 }
 
 var RouteSchema = schema.Schema{
 	Description: "Manages APISIX routes.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the route.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the route. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators(),
 		},
 		"name": schema.StringAttribute{
 			Description: "Identifier for the route.",
@@ -109,8 +119,12 @@ var RouteSchema = schema.Schema{
 			Default:  int64default.StaticInt64(0),
 		},
 		"vars": schema.StringAttribute{
+			CustomType:          JSONType{},
 			MarkdownDescription: "Matches based on the specified variables consistent with variables in Nginx. Takes the form `[[var, operator, val], [var, operator, val], ...]]`.",
 			Optional:            true,
+			Validators: []validator.String{
+				IsJSONArray(),
+			},
 		},
 		"filter_func": schema.StringAttribute{
 			MarkdownDescription: "Matches based on a user-defined filtering function." +
@@ -118,9 +132,14 @@ var RouteSchema = schema.Schema{
 			Optional: true,
 		},
 		"plugins": schema.StringAttribute{
+			CustomType:  PluginsType{},
 			Description: "Plugins that are executed during the request/response cycle.",
 			Optional:    true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
 		},
+		"typed_plugins": TypedPluginsSchemaAttribute,
 		"plugin_config_id": schema.StringAttribute{
 			Description: "Plugin config bound to the Route.",
 			Optional:    true,
@@ -133,6 +152,7 @@ var RouteSchema = schema.Schema{
 			Description: "Id of the Upstream service.",
 			Optional:    true,
 		},
+		"upstream": UpstreamInlineSchemaAttribute,
 		"service_id": schema.StringAttribute{
 			Description: "Configuration of the bound Service.",
 			Optional:    true,
@@ -159,9 +179,17 @@ var RouteSchema = schema.Schema{
 			},
 		},
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
 }
 
-func RouteFromTerraformToApi(ctx context.Context, terraformDataModel *RouteResourceModel) (apiDataModel api_client.Route) {
+// RouteDataSourceSchema returns the schema of the route data source.
+func RouteDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(RouteSchema, "id", "Retrieves an APISIX route by its identifier.")
+}
+
+func RouteFromTerraformToApi(ctx context.Context, terraformDataModel *RouteResourceModel) (apiDataModel admin.Route, diags diag.Diagnostics) {
 	apiDataModel.Name = terraformDataModel.Name.ValueStringPointer()
 	apiDataModel.Description = terraformDataModel.Description.ValueStringPointer()
 	apiDataModel.URI = terraformDataModel.URI.ValueStringPointer()
@@ -179,12 +207,23 @@ func RouteFromTerraformToApi(ctx context.Context, terraformDataModel *RouteResou
 
 	apiDataModel.Priority = terraformDataModel.Priority.ValueInt64Pointer()
 
-	apiDataModel.Vars = VarsStringToJson(ctx, terraformDataModel.Vars)
+	vars, varsDiags := VarsStringToJson(ctx, terraformDataModel.Vars, path.Root("vars"))
+	diags.Append(varsDiags...)
+	apiDataModel.Vars = vars
 
 	apiDataModel.FilterFunc = terraformDataModel.FilterFunc.ValueStringPointer()
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	plugins, pluginsDiags = TypedPluginsMerge(ctx, plugins, terraformDataModel.TypedPlugins)
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
 	apiDataModel.Script = terraformDataModel.Script.ValueStringPointer()
 	apiDataModel.UpstreamId = terraformDataModel.UpstreamId.ValueStringPointer()
+
+	upstream, upstreamDiags := UpstreamInlineFromTerraformToAPI(ctx, terraformDataModel.Upstream)
+	diags.Append(upstreamDiags...)
+	apiDataModel.Upstream = upstream
+
 	apiDataModel.ServiceId = terraformDataModel.ServiceId.ValueStringPointer()
 	apiDataModel.PluginConfigId = terraformDataModel.PluginConfigId.ValueStringPointer()
 
@@ -199,10 +238,10 @@ func RouteFromTerraformToApi(ctx context.Context, terraformDataModel *RouteResou
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func RouteFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Route) (terraformDataModel RouteResourceModel) {
+func RouteFromApiToTerraform(ctx context.Context, apiDataModel *admin.Route) (terraformDataModel RouteResourceModel, diags diag.Diagnostics) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
 	terraformDataModel.Name = types.StringPointerValue(apiDataModel.Name)
 	terraformDataModel.Description = types.StringPointerValue(apiDataModel.Description)
@@ -221,12 +260,20 @@ func RouteFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Route
 	terraformDataModel.Methods, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.Methods)
 	terraformDataModel.Priority = types.Int64PointerValue(apiDataModel.Priority)
 
-	terraformDataModel.Vars = VarsFromJsonToString(ctx, apiDataModel.Vars)
+	vars, varsDiags := VarsFromJsonToString(ctx, apiDataModel.Vars, path.Root("vars"))
+	diags.Append(varsDiags...)
+	terraformDataModel.Vars = vars
 
 	terraformDataModel.FilterFunc = types.StringPointerValue(apiDataModel.FilterFunc)
-	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	plugins, pluginsDiags := PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	terraformDataModel.Plugins = plugins
+	terraformDataModel.TypedPlugins = NewTypedPluginsNull()
 	terraformDataModel.Script = types.StringPointerValue(apiDataModel.Script)
 	terraformDataModel.UpstreamId = types.StringPointerValue(apiDataModel.UpstreamId)
+	upstream, upstreamDiags := UpstreamInlineFromApiToTerraform(ctx, apiDataModel.Upstream)
+	diags.Append(upstreamDiags...)
+	terraformDataModel.Upstream = upstream
 	terraformDataModel.ServiceId = types.StringPointerValue(apiDataModel.ServiceId)
 	terraformDataModel.PluginConfigId = types.StringPointerValue(apiDataModel.PluginConfigId)
 
@@ -236,10 +283,11 @@ func RouteFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Route
 
 	terraformDataModel.EnableWebsocket = types.BoolPointerValue(apiDataModel.EnableWebsocket)
 	terraformDataModel.Status = types.Int64PointerValue(apiDataModel.Status)
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of the RouteFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
 }
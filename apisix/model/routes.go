@@ -0,0 +1,82 @@
+package model
+
+import (
+	"context"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RoutesDataSourceModel maps the routes data source schema data.
+type RoutesDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Labels     types.Map    `tfsdk:"labels"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Host       types.String `tfsdk:"host"`
+	URI        types.String `tfsdk:"uri"`
+	Routes     types.List   `tfsdk:"routes"`
+}
+
+// RoutesDataSourceSchema returns the schema of the routes data source.
+func RoutesDataSourceSchema() (schema.Schema, error) {
+	routes, err := DataSourceListAttributeFromResourceSchema(RouteSchema, "List of the matching routes.")
+	if err != nil {
+		return schema.Schema{}, err
+	}
+
+	return schema.Schema{
+		Description: "Lists APISIX routes, optionally filtered by labels, name prefix, host or URI.",
+		Attributes: map[string]schema.Attribute{
+			"id":          ListIDSchemaAttribute,
+			"labels":      LabelsFilterSchemaAttribute,
+			"name_prefix": NamePrefixFilterSchemaAttribute,
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Only return routes which match the host with either `host` or one of the `hosts`, including the wildcard hosts such as `*.example.com`.",
+				Optional:            true,
+			},
+			"uri": schema.StringAttribute{
+				MarkdownDescription: "Only return routes which match the URI with either `uri` or one of the `uris`, including the prefix URIs such as `/api/*`.",
+				Optional:            true,
+			},
+			"routes": routes,
+		},
+	}, nil
+}
+
+func RoutesFromApiToTerraform(ctx context.Context, apiDataModel []admin.Route, filter *RoutesDataSourceModel) (terraformDataModel []RouteResourceModel, diags diag.Diagnostics) {
+	selector, diags := labelSelector(ctx, filter.Labels)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	terraformDataModel = []RouteResourceModel{}
+
+	for i := range apiDataModel {
+		route := &apiDataModel[i]
+
+		if !matchesLabels(route.Labels, selector) ||
+			!matchesPrefix(route.Name, filter.NamePrefix) ||
+			!matchesAnyValue(route.Host, route.Hosts, filter.Host, matchesHost) ||
+			!matchesAnyValue(route.URI, route.URIS, filter.URI, matchesURI) {
+			continue
+		}
+
+		routeModel, routeDiags := RouteFromApiToTerraform(ctx, route)
+		diags.Append(routeDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		terraformDataModel = append(terraformDataModel, routeModel)
+	}
+
+	tflog.Debug(ctx, "Result of the RoutesFromApiToTerraform", map[string]any{
+		"Count": len(terraformDataModel),
+	})
+
+	return terraformDataModel, diags
+}
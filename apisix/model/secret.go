@@ -0,0 +1,221 @@
+package model
+
+import (
+	"context"
+	"regexp"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	SecretManagerVault = "vault"
+	SecretManagerAWS   = "aws"
+)
+
+// SecretResourceModel maps the resource schema data.
+type SecretResourceModel struct {
+	ID       types.String     `tfsdk:"id"`
+	SecretID types.String     `tfsdk:"secret_id"`
+	Vault    *SecretVaultType `tfsdk:"vault"`
+	AWS      *SecretAWSType   `tfsdk:"aws"`
+	Timeouts timeouts.Value   `tfsdk:"timeouts"`
+}
+
+type SecretVaultType struct {
+	URI       types.String `tfsdk:"uri"`
+	Prefix    types.String `tfsdk:"prefix"`
+	Token     types.String `tfsdk:"token"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+type SecretAWSType struct {
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	SessionToken    types.String `tfsdk:"session_token"`
+	Region          types.String `tfsdk:"region"`
+	EndpointURL     types.String `tfsdk:"endpoint_url"`
+}
+
+var SecretSchema = schema.Schema{
+	MarkdownDescription: "Manages APISIX Secrets, which allow the plugins to reference the credentials stored " +
+		"in the secret manager with the `$secret://` URIs.",
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Identifier of the secret in the form of `manager/secret_id`.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"secret_id": schema.StringAttribute{
+			MarkdownDescription: "Identifier of the secret within the secret manager.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: ObjectIDValidators(),
+		},
+		"vault": schema.SingleNestedAttribute{
+			MarkdownDescription: "Configuration of the HashiCorp Vault secret manager.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.Object{
+				secretManagerRequiresReplace(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"uri": schema.StringAttribute{
+					MarkdownDescription: "URI of the Vault server, e.g. `http://127.0.0.1:8200`.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+://[^/]+`), "must be a valid URI"),
+					},
+				},
+				"prefix": schema.StringAttribute{
+					MarkdownDescription: "Path prefix of the secrets in Vault, e.g. `kv/apisix`.",
+					Required:            true,
+				},
+				"token": schema.StringAttribute{
+					MarkdownDescription: "Token used to access Vault.",
+					Required:            true,
+					Sensitive:           true,
+				},
+				"namespace": schema.StringAttribute{
+					MarkdownDescription: "Vault Enterprise namespace.",
+					Optional:            true,
+				},
+			},
+		},
+		"aws": schema.SingleNestedAttribute{
+			MarkdownDescription: "Configuration of the AWS Secrets Manager.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.Object{
+				secretManagerRequiresReplace(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"access_key_id": schema.StringAttribute{
+					MarkdownDescription: "AWS access key ID.",
+					Required:            true,
+				},
+				"secret_access_key": schema.StringAttribute{
+					MarkdownDescription: "AWS secret access key.",
+					Required:            true,
+					Sensitive:           true,
+				},
+				"session_token": schema.StringAttribute{
+					MarkdownDescription: "AWS session token for the temporary credentials.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"region": schema.StringAttribute{
+					MarkdownDescription: "AWS region. Defaults to `us-east-1`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("us-east-1"),
+				},
+				"endpoint_url": schema.StringAttribute{
+					MarkdownDescription: "Custom endpoint URL of the AWS Secrets Manager.",
+					Optional:            true,
+				},
+			},
+		},
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// secretManagerRequiresReplace replaces the secret when the secret manager is changed.
+func secretManagerRequiresReplace() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+		},
+		"Changing the secret manager requires the replacement of the secret.",
+		"Changing the secret manager requires the replacement of the secret.",
+	)
+}
+
+// SecretManager returns the secret manager of the configured block.
+func SecretManager(terraformDataModel *SecretResourceModel) string {
+	if terraformDataModel.AWS != nil {
+		return SecretManagerAWS
+	}
+
+	return SecretManagerVault
+}
+
+func SecretFromTerraformToApi(ctx context.Context, terraformDataModel *SecretResourceModel) (apiDataModel admin.Secret) {
+	if terraformDataModel.Vault != nil {
+		apiDataModel.URI = terraformDataModel.Vault.URI.ValueStringPointer()
+		apiDataModel.Prefix = terraformDataModel.Vault.Prefix.ValueStringPointer()
+		apiDataModel.Token = terraformDataModel.Vault.Token.ValueStringPointer()
+		apiDataModel.Namespace = terraformDataModel.Vault.Namespace.ValueStringPointer()
+	}
+
+	if terraformDataModel.AWS != nil {
+		apiDataModel.AccessKeyID = terraformDataModel.AWS.AccessKeyID.ValueStringPointer()
+		apiDataModel.SecretAccessKey = terraformDataModel.AWS.SecretAccessKey.ValueStringPointer()
+		apiDataModel.SessionToken = terraformDataModel.AWS.SessionToken.ValueStringPointer()
+		apiDataModel.Region = terraformDataModel.AWS.Region.ValueStringPointer()
+		apiDataModel.EndpointURL = terraformDataModel.AWS.EndpointURL.ValueStringPointer()
+	}
+
+	// Don't log the credentials
+	tflog.Debug(ctx, "Result of the SecretFromTerraformToApi", map[string]any{
+		"ID": terraformDataModel.SecretID.ValueString(),
+	})
+
+	return apiDataModel
+}
+
+func SecretFromApiToTerraform(ctx context.Context, manager string, secretID string, apiDataModel *admin.Secret) (terraformDataModel SecretResourceModel) {
+	terraformDataModel.ID = types.StringValue(manager + "/" + secretID)
+	terraformDataModel.SecretID = types.StringValue(secretID)
+
+	switch manager {
+	case SecretManagerVault:
+		terraformDataModel.Vault = &SecretVaultType{
+			URI:       types.StringPointerValue(apiDataModel.URI),
+			Prefix:    types.StringPointerValue(apiDataModel.Prefix),
+			Token:     types.StringPointerValue(apiDataModel.Token),
+			Namespace: types.StringPointerValue(apiDataModel.Namespace),
+		}
+	case SecretManagerAWS:
+		terraformDataModel.AWS = &SecretAWSType{
+			AccessKeyID:     types.StringPointerValue(apiDataModel.AccessKeyID),
+			SecretAccessKey: types.StringPointerValue(apiDataModel.SecretAccessKey),
+			SessionToken:    types.StringPointerValue(apiDataModel.SessionToken),
+			Region:          types.StringPointerValue(apiDataModel.Region),
+			EndpointURL:     types.StringPointerValue(apiDataModel.EndpointURL),
+		}
+	}
+	terraformDataModel.Timeouts = nullTimeouts()
+
+	tflog.Debug(ctx, "Result of the SecretFromApiToTerraform", map[string]any{
+		"ID": terraformDataModel.ID.ValueString(),
+	})
+
+	return terraformDataModel
+}
+
+// SecretKeepCredentials keeps the credentials from the plan or state, as APISIX can return them encrypted.
+func SecretKeepCredentials(terraformDataModel *SecretResourceModel, prior *SecretResourceModel) {
+	if terraformDataModel.Vault != nil && prior.Vault != nil {
+		terraformDataModel.Vault.Token = prior.Vault.Token
+	}
+
+	if terraformDataModel.AWS != nil && prior.AWS != nil {
+		terraformDataModel.AWS.SecretAccessKey = prior.AWS.SecretAccessKey
+		terraformDataModel.AWS.SessionToken = prior.AWS.SessionToken
+	}
+}
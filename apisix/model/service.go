@@ -3,37 +3,48 @@ package model
 import (
 	"context"
 
-	"github.com/holubovskyi/apisix-client-go"
+	"terraform-provider-apisix/apisix/admin"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // ServiceResourceModel maps the resource schema data.
 type ServiceResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"desc"`
-	EnableWebsocket types.Bool   `tfsdk:"enable_websocket"`
-	Hosts           types.List   `tfsdk:"hosts"`
-	Labels          types.Map    `tfsdk:"labels"`
-	Plugins         types.String `tfsdk:"plugins"`
-	UpstreamId      types.String `tfsdk:"upstream_id"`
+	ID              types.String        `tfsdk:"id"`
+	Name            types.String        `tfsdk:"name"`
+	Description     types.String        `tfsdk:"desc"`
+	EnableWebsocket types.Bool          `tfsdk:"enable_websocket"`
+	Hosts           types.List          `tfsdk:"hosts"`
+	Labels          types.Map           `tfsdk:"labels"`
+	Plugins         PluginsValue        `tfsdk:"plugins"`
+	TypedPlugins    types.Object        `tfsdk:"typed_plugins"`
+	UpstreamId      types.String        `tfsdk:"upstream_id"`
+	Upstream        *UpstreamInlineType `tfsdk:"upstream"`
+	Timeouts        timeouts.Value      `tfsdk:"timeouts"`
 }
 
 var ServiceSchema = schema.Schema{
 	Description: "Manages APISIX services.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the service.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the service. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators(),
 		},
 		"name": schema.StringAttribute{
 			Description: "Identifier for the service.",
@@ -60,49 +71,76 @@ var ServiceSchema = schema.Schema{
 			Optional:    true,
 		},
 		"plugins": schema.StringAttribute{
+			CustomType:  PluginsType{},
 			Description: "Plugins that are executed during the request/response cycle.",
 			Optional:    true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
 		},
+		"typed_plugins": TypedPluginsSchemaAttribute,
 		"upstream_id": schema.StringAttribute{
 			Description: "Id of the Upstream service.",
 			Optional:    true,
 		},
+		"upstream": UpstreamInlineSchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
 	},
 }
 
-func ServiceFromTerraformToApi(ctx context.Context, terraformDataModel *ServiceResourceModel) (apiDataModel api_client.Service) {
+// ServiceDataSourceSchema returns the schema of the service data source.
+func ServiceDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(ServiceSchema, "id", "Retrieves an APISIX service by its identifier.")
+}
+
+func ServiceFromTerraformToApi(ctx context.Context, terraformDataModel *ServiceResourceModel) (apiDataModel admin.Service, diags diag.Diagnostics) {
 	apiDataModel.Name = terraformDataModel.Name.ValueStringPointer()
 	apiDataModel.Description = terraformDataModel.Description.ValueStringPointer()
 	apiDataModel.EnableWebsocket = terraformDataModel.EnableWebsocket.ValueBoolPointer()
 	apiDataModel.UpstreamId = terraformDataModel.UpstreamId.ValueStringPointer()
 
+	upstream, upstreamDiags := UpstreamInlineFromTerraformToAPI(ctx, terraformDataModel.Upstream)
+	diags.Append(upstreamDiags...)
+	apiDataModel.Upstream = upstream
+
 	_ = terraformDataModel.Hosts.ElementsAs(ctx, &apiDataModel.Hosts, true)
 	_ = terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
 
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	plugins, pluginsDiags = TypedPluginsMerge(ctx, plugins, terraformDataModel.TypedPlugins)
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
 
 	tflog.Debug(ctx, "Result of ServiceFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func ServiceFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Service) (terraformDataModel ServiceResourceModel) {
+func ServiceFromApiToTerraform(ctx context.Context, apiDataModel *admin.Service) (terraformDataModel ServiceResourceModel, diags diag.Diagnostics) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
 	terraformDataModel.Name = types.StringPointerValue(apiDataModel.Name)
 	terraformDataModel.Description = types.StringPointerValue(apiDataModel.Description)
 	terraformDataModel.EnableWebsocket = types.BoolPointerValue(apiDataModel.EnableWebsocket)
 	terraformDataModel.UpstreamId = types.StringPointerValue(apiDataModel.UpstreamId)
+	terraformDataModel.Upstream, diags = UpstreamInlineFromApiToTerraform(ctx, apiDataModel.Upstream)
 
 	terraformDataModel.Hosts, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.Hosts)
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
 
-	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	plugins, pluginsDiags := PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	terraformDataModel.Plugins = plugins
+	terraformDataModel.TypedPlugins = NewTypedPluginsNull()
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of the ServiceFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
 }
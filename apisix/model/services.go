@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ServicesDataSourceModel maps the services data source schema data.
+type ServicesDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Labels     types.Map    `tfsdk:"labels"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Host       types.String `tfsdk:"host"`
+	Services   types.List   `tfsdk:"services"`
+}
+
+// ServicesDataSourceSchema returns the schema of the services data source.
+func ServicesDataSourceSchema() (schema.Schema, error) {
+	services, err := DataSourceListAttributeFromResourceSchema(ServiceSchema, "List of the matching services.")
+	if err != nil {
+		return schema.Schema{}, err
+	}
+
+	return schema.Schema{
+		Description: "Lists APISIX services, optionally filtered by labels, name prefix or host.",
+		Attributes: map[string]schema.Attribute{
+			"id":          ListIDSchemaAttribute,
+			"labels":      LabelsFilterSchemaAttribute,
+			"name_prefix": NamePrefixFilterSchemaAttribute,
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Only return services which match the host with one of the `hosts`, including the wildcard hosts such as `*.example.com`.",
+				Optional:            true,
+			},
+			"services": services,
+		},
+	}, nil
+}
+
+func ServicesFromApiToTerraform(ctx context.Context, apiDataModel []admin.Service, filter *ServicesDataSourceModel) (terraformDataModel []ServiceResourceModel, diags diag.Diagnostics) {
+	selector, diags := labelSelector(ctx, filter.Labels)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	terraformDataModel = []ServiceResourceModel{}
+
+	for i := range apiDataModel {
+		service := &apiDataModel[i]
+
+		if !matchesLabels(service.Labels, selector) ||
+			!matchesPrefix(service.Name, filter.NamePrefix) ||
+			!matchesAnyValue(nil, service.Hosts, filter.Host, matchesHost) {
+			continue
+		}
+
+		serviceModel, serviceDiags := ServiceFromApiToTerraform(ctx, service)
+		diags.Append(serviceDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		terraformDataModel = append(terraformDataModel, serviceModel)
+	}
+
+	tflog.Debug(ctx, "Result of the ServicesFromApiToTerraform", map[string]any{
+		"Count": len(terraformDataModel),
+	})
+
+	return terraformDataModel, diags
+}
@@ -3,15 +3,25 @@ package model
 import (
 	"context"
 
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"time"
 
-	"github.com/holubovskyi/apisix-client-go"
+	"terraform-provider-apisix/apisix/admin"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -31,17 +41,37 @@ type SSLCertificateResourceModel struct {
 	Snis        types.List   `tfsdk:"snis"`
 	Type        types.String `tfsdk:"type"`
 	Labels      types.Map    `tfsdk:"labels"`
+
+	AdditionalCertificates types.List `tfsdk:"additional_certificates"`
+	AdditionalPrivateKeys  types.List `tfsdk:"additional_private_keys"`
+
+	Client *SSLCertificateClientType `tfsdk:"client"`
+
+	MinDaysRemaining types.Int64 `tfsdk:"min_days_remaining"`
+
+	NotBefore         types.String   `tfsdk:"not_before"`
+	NotAfter          types.String   `tfsdk:"not_after"`
+	Issuer            types.String   `tfsdk:"issuer"`
+	Subject           types.String   `tfsdk:"subject"`
+	SerialNumber      types.String   `tfsdk:"serial_number"`
+	FingerprintSHA256 types.String   `tfsdk:"fingerprint_sha256"`
+	KeyAlgorithm      types.String   `tfsdk:"key_algorithm"`
+	KeySize           types.Int64    `tfsdk:"key_size"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 var SSLCertificateSchema = schema.Schema{
 	Description: "Manages APISIX SSL certificates.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the certificate.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the certificate. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators(),
 		},
 		"certificate": schema.StringAttribute{
 			Description: "HTTPS certificate.",
@@ -52,6 +82,27 @@ var SSLCertificateSchema = schema.Schema{
 			Required:    true,
 			Sensitive:   true,
 		},
+		"additional_certificates": schema.ListAttribute{
+			MarkdownDescription: "Additional HTTPS certificates, e.g. the ECC certificate along with the RSA one in `certificate`, " +
+				"so the same SNI is served with the certificate supported by the client. Requires `additional_private_keys`.",
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.AlsoRequires(path.MatchRoot("additional_private_keys")),
+			},
+		},
+		"additional_private_keys": schema.ListAttribute{
+			MarkdownDescription: "HTTPS private keys of the `additional_certificates` in the same order.",
+			Optional:            true,
+			Sensitive:           true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.AlsoRequires(path.MatchRoot("additional_certificates")),
+			},
+		},
+		"client": SSLCertificateClientSchemaAttribute,
 		"snis": schema.ListAttribute{
 			MarkdownDescription: "A non-empty array of HTTPS SNI. Required if `type` is `server`",
 			Optional:            true,
@@ -76,6 +127,46 @@ var SSLCertificateSchema = schema.Schema{
 			Optional:    true,
 			ElementType: types.StringType,
 		},
+		"min_days_remaining": schema.Int64Attribute{
+			MarkdownDescription: "Fails the plan when the `certificate` or one of the `additional_certificates` expires in less than the number of days, " +
+				"so the certificate is renewed before it's close to the expiry. The expired certificates always fail the plan.",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"not_before": schema.StringAttribute{
+			MarkdownDescription: "Time the `certificate` is valid from, in the RFC 3339 format.",
+			Computed:            true,
+		},
+		"not_after": schema.StringAttribute{
+			MarkdownDescription: "Time the `certificate` expires at, in the RFC 3339 format.",
+			Computed:            true,
+		},
+		"issuer": schema.StringAttribute{
+			MarkdownDescription: "Distinguished name of the `certificate` issuer, e.g. `CN=R3,O=Let's Encrypt,C=US`.",
+			Computed:            true,
+		},
+		"subject": schema.StringAttribute{
+			MarkdownDescription: "Distinguished name of the `certificate` subject.",
+			Computed:            true,
+		},
+		"serial_number": schema.StringAttribute{
+			MarkdownDescription: "Serial number of the `certificate` in the decimal form.",
+			Computed:            true,
+		},
+		"fingerprint_sha256": schema.StringAttribute{
+			MarkdownDescription: "SHA-256 fingerprint of the `certificate` in the lowercase hexadecimal form.",
+			Computed:            true,
+		},
+		"key_algorithm": schema.StringAttribute{
+			MarkdownDescription: "Algorithm of the `certificate` public key: `RSA`, `ECDSA` or `Ed25519`.",
+			Computed:            true,
+		},
+		"key_size": schema.Int64Attribute{
+			MarkdownDescription: "Size of the `certificate` public key in bits, e.g. `2048` for RSA or `256` for the P-256 curve.",
+			Computed:            true,
+		},
 		"status": schema.Int64Attribute{
 			MarkdownDescription: "Enables the current SSL. Set to `1` (enabled) by default. `1` to enable, `0` to disable",
 			Optional:            true,
@@ -87,9 +178,17 @@ var SSLCertificateSchema = schema.Schema{
 			},
 		},
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
 }
 
-func SSLCertificateFromTerraformToAPI(ctx context.Context, terraformDataModel *SSLCertificateResourceModel) (apiDataModel api_client.SSLCertificate) {
+// SSLCertificateDataSourceSchema returns the schema of the SSL certificate data source.
+func SSLCertificateDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(SSLCertificateSchema, "id", "Retrieves an APISIX SSL certificate by its identifier.", "private_key", "additional_private_keys", "min_days_remaining")
+}
+
+func SSLCertificateFromTerraformToAPI(ctx context.Context, terraformDataModel *SSLCertificateResourceModel) (apiDataModel admin.SSLCertificate) {
 	apiDataModel.Status = terraformDataModel.Status.ValueInt64Pointer()
 	apiDataModel.Certificate = terraformDataModel.Certificate.ValueStringPointer()
 	apiDataModel.PrivateKey = terraformDataModel.PrivateKey.ValueStringPointer()
@@ -97,6 +196,9 @@ func SSLCertificateFromTerraformToAPI(ctx context.Context, terraformDataModel *S
 
 	terraformDataModel.Snis.ElementsAs(ctx, &apiDataModel.SNIs, false)
 	terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, false)
+	terraformDataModel.AdditionalCertificates.ElementsAs(ctx, &apiDataModel.Certificates, false)
+	terraformDataModel.AdditionalPrivateKeys.ElementsAs(ctx, &apiDataModel.PrivateKeys, false)
+	apiDataModel.Client = SSLCertificateClientFromTerraformToAPI(ctx, terraformDataModel.Client)
 
 	tflog.Debug(ctx, "Result of the SSLCertificateFromTerraformToAPI", map[string]any{
 		"Values": apiDataModel,
@@ -105,7 +207,7 @@ func SSLCertificateFromTerraformToAPI(ctx context.Context, terraformDataModel *S
 	return apiDataModel
 }
 
-func SSLCertificateFromAPIToTerraform(ctx context.Context, apiDataModel *api_client.SSLCertificate) (terraformDataModel SSLCertificateResourceModel) {
+func SSLCertificateFromAPIToTerraform(ctx context.Context, apiDataModel *admin.SSLCertificate) (terraformDataModel SSLCertificateResourceModel) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
 	terraformDataModel.Status = types.Int64PointerValue(apiDataModel.Status)
 	terraformDataModel.Certificate = types.StringPointerValue(apiDataModel.Certificate)
@@ -115,6 +217,14 @@ func SSLCertificateFromAPIToTerraform(ctx context.Context, apiDataModel *api_cli
 
 	terraformDataModel.Snis, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.SNIs)
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
+	terraformDataModel.AdditionalCertificates, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.Certificates)
+	// The additional private keys are returned encrypted as well
+	terraformDataModel.AdditionalPrivateKeys = types.ListNull(types.StringType)
+	terraformDataModel.Client = SSLCertificateClientFromAPIToTerraform(ctx, apiDataModel.Client)
+	// The expiry guard is configured in Terraform only
+	terraformDataModel.MinDaysRemaining = types.Int64Null()
+	terraformDataModel.SetCertificateMetadata()
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of the SSLCertificateFromAPIToTerraform", map[string]any{
 		"Values": terraformDataModel,
@@ -123,6 +233,45 @@ func SSLCertificateFromAPIToTerraform(ctx context.Context, apiDataModel *api_cli
 	return terraformDataModel
 }
 
+// SetCertificateMetadata sets the computed attributes describing the certificate.
+// The attributes are null if the certificate can't be parsed.
+func (m *SSLCertificateResourceModel) SetCertificateMetadata() {
+	m.NotBefore = types.StringNull()
+	m.NotAfter = types.StringNull()
+	m.Issuer = types.StringNull()
+	m.Subject = types.StringNull()
+	m.SerialNumber = types.StringNull()
+	m.FingerprintSHA256 = types.StringNull()
+	m.KeyAlgorithm = types.StringNull()
+	m.KeySize = types.Int64Null()
+
+	certificates, err := ParsePEMCertificates(m.Certificate.ValueString())
+	if err != nil {
+		return
+	}
+
+	// The leaf certificate is the first one, followed by the intermediate certificates
+	certificate := certificates[0]
+	fingerprint := sha256.Sum256(certificate.Raw)
+
+	m.NotBefore = types.StringValue(certificate.NotBefore.UTC().Format(time.RFC3339))
+	m.NotAfter = types.StringValue(certificate.NotAfter.UTC().Format(time.RFC3339))
+	m.Issuer = types.StringValue(certificate.Issuer.String())
+	m.Subject = types.StringValue(certificate.Subject.String())
+	m.SerialNumber = types.StringValue(certificate.SerialNumber.String())
+	m.FingerprintSHA256 = types.StringValue(hex.EncodeToString(fingerprint[:]))
+	m.KeyAlgorithm = types.StringValue(certificate.PublicKeyAlgorithm.String())
+
+	switch key := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		m.KeySize = types.Int64Value(int64(key.N.BitLen()))
+	case *ecdsa.PublicKey:
+		m.KeySize = types.Int64Value(int64(key.Curve.Params().BitSize))
+	case ed25519.PublicKey:
+		m.KeySize = types.Int64Value(int64(len(key) * 8))
+	}
+}
+
 // Get SNIS list from the certificate
 func CertSNIS(crt string, key string) ([]string, error) {
 	certDERBlock, _ := pem.Decode([]byte(crt))
@@ -174,3 +323,24 @@ func CertSNIS(crt string, key string) ([]string, error) {
 
 	return snis, nil
 }
+
+// ValidateAdditionalCertificates checks each of the additional certificates matches the private key at the same index.
+func ValidateAdditionalCertificates(certificates []string, privateKeys []string) error {
+	if len(certificates) != len(privateKeys) {
+		return fmt.Errorf("got %d additional certificates and %d additional private keys, each certificate requires its private key", len(certificates), len(privateKeys))
+	}
+
+	for i := range certificates {
+		err := ValidateCertificateChain(certificates[i])
+		if err != nil {
+			return fmt.Errorf("additional certificate %d: %w", i, err)
+		}
+
+		err = ValidateCertificateKey(certificates[i], privateKeys[i])
+		if err != nil {
+			return fmt.Errorf("additional certificate %d doesn't match its private key: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SSLCertificateClientType struct {
+	CA               types.String `tfsdk:"ca"`
+	Depth            types.Int64  `tfsdk:"depth"`
+	SkipMTLSURIRegex types.List   `tfsdk:"skip_mtls_uri_regex"`
+}
+
+var SSLCertificateClientSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Verifies the certificates of the clients, i.e. enables the mutual TLS for the SNIs of the certificate.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"ca": schema.StringAttribute{
+			MarkdownDescription: "PEM encoded CA bundle used to verify the client certificates.",
+			Required:            true,
+			Validators: []validator.String{
+				IsPEMCertificates(),
+			},
+		},
+		"depth": schema.Int64Attribute{
+			MarkdownDescription: "Maximum depth of the client certificate chain. Defaults to `1`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(1),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"skip_mtls_uri_regex": schema.ListAttribute{
+			MarkdownDescription: "Regular expressions of the URIs which don't require the client certificate, e.g. the health checks.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+	},
+}
+
+func SSLCertificateClientFromTerraformToAPI(ctx context.Context, terraformDataModel *SSLCertificateClientType) (apiDataModel *admin.SSLClient) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := admin.SSLClient{
+		CA:    terraformDataModel.CA.ValueString(),
+		Depth: terraformDataModel.Depth.ValueInt64Pointer(),
+	}
+	_ = terraformDataModel.SkipMTLSURIRegex.ElementsAs(ctx, &result.SkipMTLSURIRegex, false)
+
+	return &result
+}
+
+func SSLCertificateClientFromAPIToTerraform(ctx context.Context, apiDataModel *admin.SSLClient) (terraformDataModel *SSLCertificateClientType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := SSLCertificateClientType{
+		CA: types.StringValue(apiDataModel.CA),
+		// APISIX verifies a single level of the chain unless configured
+		Depth: types.Int64Value(1),
+	}
+	if apiDataModel.Depth != nil {
+		result.Depth = types.Int64Value(*apiDataModel.Depth)
+	}
+	result.SkipMTLSURIRegex, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.SkipMTLSURIRegex)
+
+	return &result
+}
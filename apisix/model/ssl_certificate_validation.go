@@ -0,0 +1,89 @@
+package model
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// ValidateCertificateChain checks the PEM bundle starts with the leaf certificate
+// and each of the following certificates issued the previous one.
+func ValidateCertificateChain(certificate string) error {
+	certificates, err := ParsePEMCertificates(certificate)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(certificates)-1; i++ {
+		err = certificates[i].CheckSignatureFrom(certificates[i+1])
+		if err != nil {
+			return fmt.Errorf("certificate %d (%s) isn't issued by certificate %d (%s), "+
+				"the chain must start with the leaf certificate followed by its issuers: %w",
+				i+1, certificates[i].Subject, i+2, certificates[i+1].Subject, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCertificateKey checks the private key matches the leaf certificate.
+func ValidateCertificateKey(certificate string, privateKey string) error {
+	_, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+	return err
+}
+
+// ValidateCertificateSNIs checks each of the SNIs is covered by the subject alternative names of the leaf certificate,
+// or by its common name when the certificate has no subject alternative names.
+func ValidateCertificateSNIs(certificate string, snis []string) error {
+	certificates, err := ParsePEMCertificates(certificate)
+	if err != nil {
+		return err
+	}
+	leaf := certificates[0]
+
+	var uncovered []string
+	for _, sni := range snis {
+		if !certificateCoversSNI(leaf, sni) {
+			uncovered = append(uncovered, sni)
+		}
+	}
+
+	if len(uncovered) != 0 {
+		return fmt.Errorf("the SNIs %s aren't covered by the certificate names %s",
+			strings.Join(uncovered, ", "), strings.Join(certificateNames(leaf), ", "))
+	}
+
+	return nil
+}
+
+func certificateCoversSNI(certificate *x509.Certificate, sni string) bool {
+	// The wildcard SNI is covered by the same wildcard name only
+	if strings.HasPrefix(sni, "*.") {
+		for _, name := range certificateNames(certificate) {
+			if strings.EqualFold(name, sni) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(certificate.DNSNames) == 0 && len(certificate.IPAddresses) == 0 {
+		return strings.EqualFold(certificate.Subject.CommonName, sni)
+	}
+
+	return certificate.VerifyHostname(sni) == nil
+}
+
+func certificateNames(certificate *x509.Certificate) []string {
+	names := append([]string{}, certificate.DNSNames...)
+	for _, ip := range certificate.IPAddresses {
+		names = append(names, ip.String())
+	}
+
+	if len(names) == 0 && certificate.Subject.CommonName != "" {
+		names = append(names, certificate.Subject.CommonName)
+	}
+
+	return names
+}
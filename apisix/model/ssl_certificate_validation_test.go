@@ -0,0 +1,159 @@
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testValidationCertificate returns the PEM encoded certificate of the template issued by the parent
+// along with the parsed certificate. The template is self-signed when the parent is nil.
+func testValidationCertificate(t *testing.T, template *x509.Certificate, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (string, *x509.Certificate) {
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(24 * time.Hour)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	data, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: data})), certificate
+}
+
+func testValidationKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestValidateCertificateChain(t *testing.T) {
+	caKey := testValidationKey(t)
+	caCertificate, ca := testValidationCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Example CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, caKey, nil, nil)
+	leafCertificate, _ := testValidationCertificate(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "api.example.com"},
+		DNSNames: []string{"api.example.com"},
+	}, testValidationKey(t), ca, caKey)
+	otherCertificate, _ := testValidationCertificate(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "other.example.com"},
+		DNSNames: []string{"other.example.com"},
+	}, testValidationKey(t), nil, nil)
+
+	testCases := map[string]struct {
+		certificate string
+		expectError string
+	}{
+		"leaf certificate only":       {certificate: leafCertificate},
+		"leaf followed by its issuer": {certificate: leafCertificate + caCertificate},
+		"issuer followed by the leaf": {certificate: caCertificate + leafCertificate, expectError: "certificate 1 (CN=Example CA) isn't issued by certificate 2 (CN=api.example.com)"},
+		"unrelated certificates":      {certificate: leafCertificate + otherCertificate, expectError: "isn't issued by certificate 2 (CN=other.example.com)"},
+		"not a certificate":           {certificate: "not a certificate", expectError: "certificate"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCertificateChain(testCase.certificate)
+			if testCase.expectError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.expectError) {
+				t.Fatalf("expected the error containing %q, got %v", testCase.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateCertificateSNIs(t *testing.T) {
+	certificate, _ := testValidationCertificate(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com", "*.example.com"},
+	}, testValidationKey(t), nil, nil)
+
+	err := ValidateCertificateSNIs(certificate, []string{"example.com", "api.example.com", "*.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Only the uncovered SNIs are reported, along with the names of the certificate
+	err = ValidateCertificateSNIs(certificate, []string{"api.example.com", "a.b.example.com", "*.other.com"})
+	if err == nil {
+		t.Fatal("expected the uncovered SNIs to be reported")
+	}
+	expected := "the SNIs a.b.example.com, *.other.com aren't covered by the certificate names example.com, *.example.com"
+	if err.Error() != expected {
+		t.Errorf("expected the error %q, got %q", expected, err)
+	}
+}
+
+func TestCertificateCoversSNI(t *testing.T) {
+	key := testValidationKey(t)
+	_, wildcard := testValidationCertificate(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "wildcard"},
+		DNSNames: []string{"*.example.com"},
+	}, key, nil, nil)
+	_, commonNameOnly := testValidationCertificate(t, &x509.Certificate{
+		Subject: pkix.Name{CommonName: "legacy.example.com"},
+	}, key, nil, nil)
+	_, ipAddress := testValidationCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "ignored.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+	}, key, nil, nil)
+
+	testCases := map[string]struct {
+		certificate *x509.Certificate
+		sni         string
+		covered     bool
+	}{
+		"wildcard covers the subdomain":          {certificate: wildcard, sni: "api.example.com", covered: true},
+		"wildcard covers the subdomain any case": {certificate: wildcard, sni: "API.Example.com", covered: true},
+		"wildcard doesn't cover the apex":        {certificate: wildcard, sni: "example.com"},
+		"wildcard doesn't cover two labels":      {certificate: wildcard, sni: "a.b.example.com"},
+		"wildcard SNI covered by the same name":  {certificate: wildcard, sni: "*.example.com", covered: true},
+		"wildcard SNI not covered by other name": {certificate: wildcard, sni: "*.api.example.com"},
+		"common name without SANs":               {certificate: commonNameOnly, sni: "legacy.example.com", covered: true},
+		"common name without SANs any case":      {certificate: commonNameOnly, sni: "Legacy.Example.com", covered: true},
+		"other name than the common name":        {certificate: commonNameOnly, sni: "api.example.com"},
+		"wildcard SNI of the common name":        {certificate: commonNameOnly, sni: "*.example.com"},
+		"IP SAN":                                 {certificate: ipAddress, sni: "10.0.0.1", covered: true},
+		"other IP than the SAN":                  {certificate: ipAddress, sni: "10.0.0.2"},
+		"common name ignored with the IP SAN":    {certificate: ipAddress, sni: "ignored.example.com"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			covered := certificateCoversSNI(testCase.certificate, testCase.sni)
+			if covered != testCase.covered {
+				t.Errorf("expected the SNI %s to be covered %t, got %t", testCase.sni, testCase.covered, covered)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 
-	"github.com/holubovskyi/apisix-client-go"
+	"terraform-provider-apisix/apisix/admin"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -16,27 +22,45 @@ import (
 
 // StreamRouteModel maps the resource schema data.
 type StreamRouteModel struct {
-	ID         types.String `tfsdk:"id"`
-	UpstreamId types.String `tfsdk:"upstream_id"`
-	RemoteAddr types.String `tfsdk:"remote_addr"`
-	ServerAddr types.String `tfsdk:"server_addr"`
-	ServerPort types.Int64  `tfsdk:"server_port"`
-	SNI        types.String `tfsdk:"sni"`
+	ID         types.String             `tfsdk:"id"`
+	UpstreamId types.String             `tfsdk:"upstream_id"`
+	Upstream   *UpstreamInlineType      `tfsdk:"upstream"`
+	ServiceId  types.String             `tfsdk:"service_id"`
+	RemoteAddr types.String             `tfsdk:"remote_addr"`
+	ServerAddr types.String             `tfsdk:"server_addr"`
+	ServerPort types.Int64              `tfsdk:"server_port"`
+	SNI        types.String             `tfsdk:"sni"`
+	Plugins    PluginsValue             `tfsdk:"plugins"`
+	Protocol   *StreamRouteProtocolType `tfsdk:"protocol"`
+	Timeouts   timeouts.Value           `tfsdk:"timeouts"`
+}
+
+type StreamRouteProtocolType struct {
+	Name types.String `tfsdk:"name"`
+	Conf JSONValue    `tfsdk:"conf"`
 }
 
 var StreamRouteSchema = schema.Schema{
 	Description: "Manages APISIX Routes used in the Stream Proxy.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the stream route.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the stream route. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators(),
 		},
 		"upstream_id": schema.StringAttribute{
 			Description: "Id of the Upstream service.",
-			Required:    true,
+			Optional:    true,
+		},
+		"upstream": UpstreamInlineSchemaAttribute,
+		"service_id": schema.StringAttribute{
+			Description: "Configuration of the bound Service.",
+			Optional:    true,
 		},
 		"remote_addr": schema.StringAttribute{
 			MarkdownDescription: "Filters Upstream forwards by matching with client IP. IPv4 (`127.0.0.1`) OR CIDR format (`127.0.0.1/32`).",
@@ -57,34 +81,146 @@ var StreamRouteSchema = schema.Schema{
 			MarkdownDescription: "Server Name Indication. Matches with domain names such as `foo.com`",
 			Optional:            true,
 		},
+		"plugins": schema.StringAttribute{
+			CustomType:          PluginsType{},
+			MarkdownDescription: "Stream Plugins that are executed during the connection, such as `limit-conn`, `ip-restriction` or `mqtt-proxy`.",
+			Optional:            true,
+			Validators: []validator.String{
+				IsJSONObject(),
+			},
+		},
+		"protocol": schema.SingleNestedAttribute{
+			MarkdownDescription: "xRPC protocol used to proxy the connection.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the xRPC protocol, e.g. `redis` or `dubbo`.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"conf": schema.StringAttribute{
+					CustomType:          JSONType{},
+					MarkdownDescription: "Configuration of the xRPC protocol.",
+					Optional:            true,
+					Validators: []validator.String{
+						IsJSONObject(),
+					},
+				},
+			},
+		},
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// StreamRouteDataSourceSchema returns the schema of the stream route data source.
+func StreamRouteDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(StreamRouteSchema, "id", "Retrieves an APISIX stream route by its identifier.")
 }
 
-func StreamRouteFromTerraformToApi(ctx context.Context, terraformDataModel *StreamRouteModel) (apiDataModel api_client.StreamRoute) {
+func StreamRouteFromTerraformToApi(ctx context.Context, terraformDataModel *StreamRouteModel) (apiDataModel admin.StreamRoute, diags diag.Diagnostics) {
 	apiDataModel.UpstreamId = terraformDataModel.UpstreamId.ValueStringPointer()
+	apiDataModel.ServiceId = terraformDataModel.ServiceId.ValueStringPointer()
 	apiDataModel.RemoteAddr = terraformDataModel.RemoteAddr.ValueStringPointer()
 	apiDataModel.ServerAddr = terraformDataModel.ServerAddr.ValueStringPointer()
 	apiDataModel.ServerPort = terraformDataModel.ServerPort.ValueInt64Pointer()
 	apiDataModel.SNI = terraformDataModel.SNI.ValueStringPointer()
 
+	upstream, upstreamDiags := UpstreamInlineFromTerraformToAPI(ctx, terraformDataModel.Upstream)
+	diags.Append(upstreamDiags...)
+	apiDataModel.Upstream = upstream
+
+	plugins, pluginsDiags := PluginsStringToJson(ctx, terraformDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	apiDataModel.Plugins = plugins
+
+	protocol, protocolDiags := StreamRouteProtocolFromTerraformToApi(terraformDataModel.Protocol)
+	diags.Append(protocolDiags...)
+	apiDataModel.Protocol = protocol
+
 	tflog.Debug(ctx, "Result of the StreamRouteFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
 	})
 
-	return apiDataModel
+	return apiDataModel, diags
 }
 
-func StreamRouteFromApiToTerraform(ctx context.Context, apiDataModel *api_client.StreamRoute) (terraformDataModel StreamRouteModel) {
+func StreamRouteFromApiToTerraform(ctx context.Context, apiDataModel *admin.StreamRoute) (terraformDataModel StreamRouteModel, diags diag.Diagnostics) {
 	terraformDataModel.ID = types.StringPointerValue(apiDataModel.ID)
 	terraformDataModel.UpstreamId = types.StringPointerValue(apiDataModel.UpstreamId)
+	terraformDataModel.ServiceId = types.StringPointerValue(apiDataModel.ServiceId)
 	terraformDataModel.RemoteAddr = types.StringPointerValue(apiDataModel.RemoteAddr)
 	terraformDataModel.ServerAddr = types.StringPointerValue(apiDataModel.ServerAddr)
 	terraformDataModel.ServerPort = types.Int64PointerValue(apiDataModel.ServerPort)
 	terraformDataModel.SNI = types.StringPointerValue(apiDataModel.SNI)
 
+	terraformDataModel.Upstream, diags = UpstreamInlineFromApiToTerraform(ctx, apiDataModel.Upstream)
+	plugins, pluginsDiags := PluginsFromJsonToString(ctx, apiDataModel.Plugins, path.Root("plugins"))
+	diags.Append(pluginsDiags...)
+	terraformDataModel.Plugins = plugins
+
+	protocol, protocolDiags := StreamRouteProtocolFromApiToTerraform(apiDataModel.Protocol)
+	diags.Append(protocolDiags...)
+	terraformDataModel.Protocol = protocol
+	terraformDataModel.Timeouts = nullTimeouts()
+
 	tflog.Debug(ctx, "Result of the StreamRouteFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
 	})
 
-	return terraformDataModel
+	return terraformDataModel, diags
+}
+
+func StreamRouteProtocolFromTerraformToApi(terraformDataModel *StreamRouteProtocolType) (apiDataModel *admin.StreamRouteProtocol, diags diag.Diagnostics) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := admin.StreamRouteProtocol{
+		Name: terraformDataModel.Name.ValueString(),
+	}
+
+	if !terraformDataModel.Conf.IsNull() {
+		var conf map[string]interface{}
+		err := json.Unmarshal([]byte(terraformDataModel.Conf.ValueString()), &conf)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("protocol").AtName("conf"),
+				"Error Converting Protocol Configuration",
+				"Could not convert protocol configuration to JSON object: "+err.Error(),
+			)
+			return nil, diags
+		}
+		result.Conf = &conf
+	}
+
+	return &result, diags
+}
+
+func StreamRouteProtocolFromApiToTerraform(apiDataModel *admin.StreamRouteProtocol) (terraformDataModel *StreamRouteProtocolType, diags diag.Diagnostics) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := StreamRouteProtocolType{
+		Name: types.StringValue(apiDataModel.Name),
+		Conf: NewJSONNull(),
+	}
+
+	if apiDataModel.Conf != nil {
+		data, err := json.Marshal(apiDataModel.Conf)
+		if err != nil {
+			diags.AddError(
+				"Error Converting Protocol Configuration",
+				"Could not convert protocol configuration to string: "+err.Error(),
+			)
+			return nil, diags
+		}
+		result.Conf = NewJSONValue(string(data))
+	}
+
+	return &result, diags
 }
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsBlock returns the timeouts block of the resource operations.
+func timeoutsBlock() schema.Block {
+	block := timeouts.Block(context.Background(), timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	}).(schema.SingleNestedBlock)
+	block.MarkdownDescription = "Timeouts of the resource operations as durations, e.g. `30s` or `5m`. Each of them defaults to `5m`."
+
+	return block
+}
+
+// nullTimeouts returns the timeouts of the models converted from the APISIX API,
+// which are replaced with the configured ones by the resources.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(timeoutsBlock().Type().(timeouts.Type).AttrTypes),
+	}
+}
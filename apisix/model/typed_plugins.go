@@ -0,0 +1,727 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The typed plugins are the nested attributes of the frequently used plugins. Their names are
+// the plugin names with `-` replaced by `_`, and the names of their attributes match the fields
+// of the plugin configuration, so the conversion from and to the plugins JSON is generic.
+//
+// The fields which have the default values in APISIX are optional and computed, because
+// the defaults depend on the APISIX version and where the plugin is configured,
+// e.g. `key-auth` has the different schemas on the routes and the consumers.
+
+// optionalComputedString returns the optional attribute, which defaults to the value set by APISIX.
+func optionalComputedString(description string, validators ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		Validators: validators,
+	}
+}
+
+func optionalComputedInt64(description string, validators ...validator.Int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+		Validators: validators,
+	}
+}
+
+func optionalComputedBool(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+var limitCountSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `limit-count` plugin, which limits the number of requests within the time window.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"count": schema.Int64Attribute{
+			MarkdownDescription: "Maximum number of requests allowed within the time window.",
+			Required:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"time_window": schema.Int64Attribute{
+			MarkdownDescription: "Time window in seconds.",
+			Required:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"key_type": optionalComputedString("Type of the `key`, one of `var`, `var_combination` or `constant`. Defaults to `var` in APISIX.",
+			stringvalidator.OneOf("var", "var_combination", "constant"),
+		),
+		"key":           optionalComputedString("Key to count the requests by, such as `remote_addr`. Defaults to `remote_addr` in APISIX."),
+		"rejected_code": optionalComputedInt64("HTTP status code returned when the limit is exceeded. Defaults to `503` in APISIX.", int64validator.Between(200, 599)),
+		"rejected_msg": schema.StringAttribute{
+			MarkdownDescription: "Response body returned when the limit is exceeded.",
+			Optional:            true,
+		},
+		"policy": optionalComputedString("Rate limiting policy, one of `local`, `redis` or `redis-cluster`. Defaults to `local` in APISIX.",
+			stringvalidator.OneOf("local", "redis", "redis-cluster"),
+		),
+		"group": schema.StringAttribute{
+			MarkdownDescription: "Group to share the counter between the routes.",
+			Optional:            true,
+		},
+		"allow_degradation":       optionalComputedBool("Whether to allow the requests when the plugin fails. Defaults to `false` in APISIX."),
+		"show_limit_quota_header": optionalComputedBool("Whether to return the `X-RateLimit-Limit` and `X-RateLimit-Remaining` headers. Defaults to `true` in APISIX."),
+		"redis_host": schema.StringAttribute{
+			MarkdownDescription: "Address of the Redis node. Required with the `redis` policy.",
+			Optional:            true,
+		},
+		"redis_port": optionalComputedInt64("Port of the Redis node. Defaults to `6379` in APISIX with the `redis` policy.", int64validator.Between(1, 65535)),
+		"redis_username": schema.StringAttribute{
+			MarkdownDescription: "Username of the Redis ACL authentication.",
+			Optional:            true,
+		},
+		"redis_password": schema.StringAttribute{
+			MarkdownDescription: "Password of the Redis authentication.",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"redis_database": optionalComputedInt64("Database of the Redis node. Defaults to `0` in APISIX with the `redis` policy.", int64validator.AtLeast(0)),
+		"redis_timeout":  optionalComputedInt64("Timeout in milliseconds of the Redis operations. Defaults to `1000` in APISIX with the Redis policies.", int64validator.AtLeast(1)),
+	},
+}
+
+var limitReqSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `limit-req` plugin, which limits the request rate with the leaky bucket.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"rate": schema.Float64Attribute{
+			MarkdownDescription: "Number of requests per second allowed.",
+			Required:            true,
+			Validators: []validator.Float64{
+				float64validator.AtLeast(0),
+			},
+		},
+		"burst": schema.Float64Attribute{
+			MarkdownDescription: "Number of requests per second delayed above the rate.",
+			Required:            true,
+			Validators: []validator.Float64{
+				float64validator.AtLeast(0),
+			},
+		},
+		"key": schema.StringAttribute{
+			MarkdownDescription: "Key to limit the requests by, such as `remote_addr`.",
+			Required:            true,
+		},
+		"key_type": optionalComputedString("Type of the `key`, one of `var` or `var_combination`. Defaults to `var` in APISIX.",
+			stringvalidator.OneOf("var", "var_combination"),
+		),
+		"rejected_code": optionalComputedInt64("HTTP status code returned when the limit is exceeded. Defaults to `503` in APISIX.", int64validator.Between(200, 599)),
+		"rejected_msg": schema.StringAttribute{
+			MarkdownDescription: "Response body returned when the limit is exceeded.",
+			Optional:            true,
+		},
+		"nodelay":           optionalComputedBool("Whether to forward the burst requests without the delay. Defaults to `false` in APISIX."),
+		"allow_degradation": optionalComputedBool("Whether to allow the requests when the plugin fails. Defaults to `false` in APISIX."),
+	},
+}
+
+var keyAuthSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `key-auth` plugin. Set `key` on the consumers, and `header` or `query` on the routes and services.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			MarkdownDescription: "Unique key of the consumer.",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"header":           optionalComputedString("Header to get the key from. Defaults to `apikey` in APISIX."),
+		"query":            optionalComputedString("Query string to get the key from. Defaults to `apikey` in APISIX."),
+		"hide_credentials": optionalComputedBool("Whether to remove the key from the request before proxying it. Defaults to `false` in APISIX."),
+	},
+}
+
+var jwtAuthSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `jwt-auth` plugin. Set `key` and the signing attributes on the consumers, " +
+		"and `header`, `query` or `cookie` on the routes and services.",
+	Optional: true,
+	Attributes: map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			MarkdownDescription: "Unique key of the consumer.",
+			Optional:            true,
+		},
+		"secret": schema.StringAttribute{
+			MarkdownDescription: "Secret used to sign the tokens with the `HS256` or `HS512` algorithm. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
+			Sensitive:           true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"public_key": schema.StringAttribute{
+			MarkdownDescription: "RSA or ECDSA public key used with the `RS256` or `ES256` algorithm.",
+			Optional:            true,
+		},
+		"algorithm": optionalComputedString("Signing algorithm, one of `HS256`, `HS512`, `RS256` or `ES256`. Defaults to `HS256` in APISIX.",
+			stringvalidator.OneOf("HS256", "HS512", "RS256", "ES256"),
+		),
+		"exp":                   optionalComputedInt64("Expiry time of the token in seconds. Defaults to `86400` in APISIX.", int64validator.AtLeast(1)),
+		"base64_secret":         optionalComputedBool("Whether the secret is base64 encoded. Defaults to `false` in APISIX."),
+		"lifetime_grace_period": optionalComputedInt64("Clock skew in seconds allowed during the token verification. Defaults to `0` in APISIX.", int64validator.AtLeast(0)),
+		"header":                optionalComputedString("Header to get the token from. Defaults to `authorization` in APISIX."),
+		"query":                 optionalComputedString("Query string to get the token from. Defaults to `jwt` in APISIX."),
+		"cookie":                optionalComputedString("Cookie to get the token from. Defaults to `jwt` in APISIX."),
+		"hide_credentials":      optionalComputedBool("Whether to remove the token from the request before proxying it. Defaults to `false` in APISIX."),
+	},
+}
+
+var corsSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `cors` plugin, which enables the Cross-Origin Resource Sharing.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"allow_origins":    optionalComputedString("Comma separated origins allowed, such as `https://foo.com,https://bar.com`, or `*`. Defaults to `*` in APISIX."),
+		"allow_methods":    optionalComputedString("Comma separated HTTP methods allowed, or `*`. Defaults to `*` in APISIX."),
+		"allow_headers":    optionalComputedString("Comma separated request headers allowed, or `*`. Defaults to `*` in APISIX."),
+		"expose_headers":   optionalComputedString("Comma separated response headers exposed to the browser."),
+		"max_age":          optionalComputedInt64("Time in seconds the preflight result is cached. Defaults to `5` in APISIX."),
+		"allow_credential": optionalComputedBool("Whether to allow the credentials, which requires the explicit origins. Defaults to `false` in APISIX."),
+		"allow_origins_by_regex": schema.ListAttribute{
+			MarkdownDescription: "Regular expressions matching the allowed origins.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+	},
+}
+
+var proxyRewriteSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `proxy-rewrite` plugin, which rewrites the requests forwarded to the upstream.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"uri": schema.StringAttribute{
+			MarkdownDescription: "New path of the upstream request.",
+			Optional:            true,
+		},
+		"method": schema.StringAttribute{
+			MarkdownDescription: "New HTTP method of the upstream request.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("GET", "POST", "PUT", "HEAD", "DELETE", "OPTIONS", "MKCOL", "COPY", "MOVE", "PROPFIND", "LOCK", "UNLOCK", "PATCH", "TRACE"),
+			},
+		},
+		"regex_uri": schema.ListAttribute{
+			MarkdownDescription: "Regular expression and the template of the new path, e.g. `[\"^/api/(.*)\", \"/$1\"]`.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeBetween(2, 2),
+			},
+		},
+		"host": schema.StringAttribute{
+			MarkdownDescription: "New `Host` header of the upstream request.",
+			Optional:            true,
+		},
+		"headers": schema.SingleNestedAttribute{
+			MarkdownDescription: "Headers of the upstream request to change.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"add": schema.MapAttribute{
+					MarkdownDescription: "Headers to append.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"set": schema.MapAttribute{
+					MarkdownDescription: "Headers to overwrite.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"remove": schema.ListAttribute{
+					MarkdownDescription: "Headers to remove.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+		"use_real_request_uri_unsafe": optionalComputedBool("Whether to forward the original request URI without the normalization. Defaults to `false` in APISIX."),
+	},
+}
+
+var prometheusSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `prometheus` plugin, which exports the metrics.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"prefer_name": optionalComputedBool("Whether to export the name of the route or service instead of the identifier. Defaults to `false` in APISIX."),
+	},
+}
+
+var ipRestrictionSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Configuration of the `ip-restriction` plugin. Exactly one of `whitelist` or `blacklist` is required.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"whitelist": schema.ListAttribute{
+			MarkdownDescription: "IP addresses or CIDR ranges allowed.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("blacklist")),
+			},
+		},
+		"blacklist": schema.ListAttribute{
+			MarkdownDescription: "IP addresses or CIDR ranges denied.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+		"message": optionalComputedString("Response body returned to the denied addresses. Defaults to `Your IP address is not allowed` in APISIX.",
+			stringvalidator.LengthBetween(1, 1024),
+		),
+	},
+}
+
+// TypedPluginsSchemaAttribute is the attribute of the typed plugins, which are merged with the `plugins` JSON.
+var TypedPluginsSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Typed configuration of the frequently used plugins, which is merged with the `plugins` JSON. " +
+		"The same plugin can't be configured in both of them.",
+	Optional: true,
+	Attributes: map[string]schema.Attribute{
+		"limit_count":    limitCountSchemaAttribute,
+		"limit_req":      limitReqSchemaAttribute,
+		"key_auth":       keyAuthSchemaAttribute,
+		"jwt_auth":       jwtAuthSchemaAttribute,
+		"cors":           corsSchemaAttribute,
+		"proxy_rewrite":  proxyRewriteSchemaAttribute,
+		"prometheus":     prometheusSchemaAttribute,
+		"ip_restriction": ipRestrictionSchemaAttribute,
+	},
+}
+
+// typedPluginsType is the object type of the typed plugins.
+var typedPluginsType = TypedPluginsSchemaAttribute.GetType().(types.ObjectType)
+
+// NewTypedPluginsNull returns the typed plugins, which are not configured.
+func NewTypedPluginsNull() types.Object {
+	return types.ObjectNull(typedPluginsType.AttrTypes)
+}
+
+// typedPluginName returns the name of the plugin configured with the typed attribute.
+func typedPluginName(attributeName string) string {
+	return strings.ReplaceAll(attributeName, "_", "-")
+}
+
+// TypedPluginsMerge adds the configured typed plugins to the plugins converted from the `plugins` JSON.
+func TypedPluginsMerge(ctx context.Context, plugins *map[string]interface{}, typedPlugins types.Object) (result *map[string]interface{}, diags diag.Diagnostics) {
+	if typedPlugins.IsNull() || typedPlugins.IsUnknown() {
+		return plugins, diags
+	}
+
+	merged := map[string]interface{}{}
+	if plugins != nil {
+		for name, config := range *plugins {
+			merged[name] = config
+		}
+	}
+
+	for attributeName, value := range typedPlugins.Attributes() {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		name := typedPluginName(attributeName)
+		if _, ok := merged[name]; ok {
+			diags.AddAttributeError(
+				path.Root("typed_plugins").AtName(attributeName),
+				"Conflicting Plugin Configuration",
+				"The "+name+" plugin is configured in both the plugins JSON and the typed_plugins attribute.",
+			)
+			continue
+		}
+
+		terraformValue, err := value.ToTerraformValue(ctx)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("typed_plugins").AtName(attributeName),
+				"Error Converting Plugins",
+				"Could not convert the "+name+" plugin to JSON object: "+err.Error(),
+			)
+			continue
+		}
+
+		config, err := terraformValueToJSON(terraformValue)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("typed_plugins").AtName(attributeName),
+				"Error Converting Plugins",
+				"Could not convert the "+name+" plugin to JSON object: "+err.Error(),
+			)
+			continue
+		}
+
+		merged[name] = config
+	}
+
+	return &merged, diags
+}
+
+// TypedPluginsSplit moves the plugins configured in the prior typed plugins from the `plugins` JSON
+// returned by APISIX to the typed plugins. The rest of the plugins remain in the `plugins` JSON,
+// so the imported objects have all the plugins in the JSON.
+func TypedPluginsSplit(ctx context.Context, plugins *PluginsValue, typedPlugins *types.Object, prior types.Object) (diags diag.Diagnostics) {
+	*typedPlugins = NewTypedPluginsNull()
+	if prior.IsNull() || prior.IsUnknown() || plugins.IsNull() {
+		return diags
+	}
+
+	var apiPlugins map[string]interface{}
+	err := json.Unmarshal([]byte(plugins.ValueString()), &apiPlugins)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("plugins"),
+			"Error Converting Plugins",
+			"Could not convert plugins to JSON object: "+err.Error(),
+		)
+		return diags
+	}
+
+	priorAttributes := prior.Attributes()
+	values := map[string]tftypes.Value{}
+	moved := false
+
+	for attributeName, attributeType := range typedPluginsType.AttrTypes {
+		terraformType := attributeType.TerraformType(ctx)
+		values[attributeName] = tftypes.NewValue(terraformType, nil)
+
+		priorValue, ok := priorAttributes[attributeName]
+		if !ok || priorValue.IsNull() || priorValue.IsUnknown() {
+			continue
+		}
+
+		name := typedPluginName(attributeName)
+		config, ok := apiPlugins[name]
+		if !ok {
+			// The plugin was removed outside of Terraform
+			continue
+		}
+
+		priorTerraformValue, err := priorValue.ToTerraformValue(ctx)
+		if err == nil {
+			values[attributeName], err = pluginConfigToTerraformValue(ctx, attributeName, config, priorTerraformValue)
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("typed_plugins").AtName(attributeName),
+				"Error Converting Plugins",
+				"Could not convert the "+name+" plugin returned by APISIX: "+err.Error(),
+			)
+			return diags
+		}
+
+		delete(apiPlugins, name)
+		moved = true
+	}
+
+	if !moved {
+		return diags
+	}
+
+	value, err := typedPluginsType.ValueFromTerraform(ctx, tftypes.NewValue(typedPluginsType.TerraformType(ctx), values))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("typed_plugins"),
+			"Error Converting Plugins",
+			"Could not convert the typed plugins: "+err.Error(),
+		)
+		return diags
+	}
+	*typedPlugins = value.(types.Object)
+
+	// Keep the plugins JSON unset, when all the plugins are typed
+	if len(apiPlugins) == 0 {
+		*plugins = NewPluginsNull()
+		return diags
+	}
+	*plugins, diags = PluginsFromJsonToString(ctx, &apiPlugins, path.Root("plugins"))
+
+	return diags
+}
+
+// pluginConfigToTerraformValue converts the plugin configuration returned by APISIX to the typed plugin.
+// The sensitive fields keep the prior values, as APISIX can return them encrypted, and the fields,
+// which are not returned by APISIX, keep the prior values as well.
+func pluginConfigToTerraformValue(ctx context.Context, attributeName string, config interface{}, prior tftypes.Value) (tftypes.Value, error) {
+	pluginAttribute := TypedPluginsSchemaAttribute.Attributes[attributeName].(schema.SingleNestedAttribute)
+	pluginType := pluginAttribute.GetType().(types.ObjectType)
+
+	configObject, ok := config.(map[string]interface{})
+	if !ok {
+		return tftypes.Value{}, fmt.Errorf("expected JSON object, got: %T", config)
+	}
+
+	var priorValues map[string]tftypes.Value
+	err := prior.As(&priorValues)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range pluginType.AttrTypes {
+		priorValue := priorValues[name]
+		field, ok := configObject[name]
+
+		switch {
+		case pluginAttribute.Attributes[name].IsSensitive() && priorValue.IsKnown() && !priorValue.IsNull():
+			values[name] = priorValue
+		case ok:
+			values[name], err = jsonToTerraformValue(ctx, attributeType, field)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+		case priorValue.IsKnown():
+			values[name] = priorValue
+		default:
+			values[name] = tftypes.NewValue(attributeType.TerraformType(ctx), nil)
+		}
+	}
+
+	return tftypes.NewValue(pluginType.TerraformType(ctx), values), nil
+}
+
+// jsonToTerraformValue converts the decoded JSON value to the Terraform value of the attribute type.
+func jsonToTerraformValue(ctx context.Context, attributeType attr.Type, value interface{}) (tftypes.Value, error) {
+	terraformType := attributeType.TerraformType(ctx)
+	if value == nil {
+		return tftypes.NewValue(terraformType, nil), nil
+	}
+
+	switch attributeType := attributeType.(type) {
+	case basetypes.StringType:
+		if _, ok := value.(string); !ok {
+			return tftypes.Value{}, fmt.Errorf("expected string, got: %T", value)
+		}
+		return tftypes.NewValue(terraformType, value), nil
+	case basetypes.BoolType:
+		if _, ok := value.(bool); !ok {
+			return tftypes.Value{}, fmt.Errorf("expected boolean, got: %T", value)
+		}
+		return tftypes.NewValue(terraformType, value), nil
+	case basetypes.Int64Type, basetypes.Float64Type, basetypes.NumberType:
+		number, ok := value.(float64)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected number, got: %T", value)
+		}
+		return tftypes.NewValue(terraformType, big.NewFloat(number)), nil
+	case types.ListType:
+		list, ok := value.([]interface{})
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected array, got: %T", value)
+		}
+
+		elements := []tftypes.Value{}
+		for _, element := range list {
+			elementValue, err := jsonToTerraformValue(ctx, attributeType.ElemType, element)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elements = append(elements, elementValue)
+		}
+		return tftypes.NewValue(terraformType, elements), nil
+	case types.MapType:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected object, got: %T", value)
+		}
+
+		elements := map[string]tftypes.Value{}
+		for key, element := range object {
+			elementValue, err := jsonToTerraformValue(ctx, attributeType.ElemType, element)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elements[key] = elementValue
+		}
+		return tftypes.NewValue(terraformType, elements), nil
+	case types.ObjectType:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected object, got: %T", value)
+		}
+
+		fields := map[string]tftypes.Value{}
+		for name, fieldType := range attributeType.AttrTypes {
+			fieldValue, err := jsonToTerraformValue(ctx, fieldType, object[name])
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			fields[name] = fieldValue
+		}
+		return tftypes.NewValue(terraformType, fields), nil
+	}
+
+	return tftypes.Value{}, fmt.Errorf("unsupported type %s", attributeType)
+}
+
+// terraformValueToJSON converts the Terraform value to the JSON value, skipping the unset object fields.
+func terraformValueToJSON(value tftypes.Value) (interface{}, error) {
+	if value.IsNull() || !value.IsKnown() {
+		return nil, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var result string
+		err := value.As(&result)
+		return result, err
+	case value.Type().Is(tftypes.Bool):
+		var result bool
+		err := value.As(&result)
+		return result, err
+	case value.Type().Is(tftypes.Number):
+		var number big.Float
+		err := value.As(&number)
+		if err != nil {
+			return nil, err
+		}
+		if number.IsInt() {
+			result, _ := number.Int64()
+			return result, nil
+		}
+		result, _ := number.Float64()
+		return result, nil
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		err := value.As(&elements)
+		if err != nil {
+			return nil, err
+		}
+
+		result := []interface{}{}
+		for _, element := range elements {
+			elementValue, err := terraformValueToJSON(element)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, elementValue)
+		}
+		return result, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var fields map[string]tftypes.Value
+		err := value.As(&fields)
+		if err != nil {
+			return nil, err
+		}
+
+		result := map[string]interface{}{}
+		for _, name := range sortedNames(fields) {
+			if fields[name].IsNull() || !fields[name].IsKnown() {
+				continue
+			}
+
+			fieldValue, err := terraformValueToJSON(fields[name])
+			if err != nil {
+				return nil, err
+			}
+			result[name] = fieldValue
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", value.Type())
+}
+
+func sortedNames(values map[string]tftypes.Value) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// TypedPluginsConfigValidator returns the validator of the plugins configured in both
+// the `plugins` JSON and the typed plugins.
+func TypedPluginsConfigValidator() resource.ConfigValidator {
+	return typedPluginsConfigValidator{}
+}
+
+type typedPluginsConfigValidator struct{}
+
+func (v typedPluginsConfigValidator) Description(_ context.Context) string {
+	return "The same plugin can't be configured in both plugins and typed_plugins."
+}
+
+func (v typedPluginsConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return "The same plugin can't be configured in both `plugins` and `typed_plugins`."
+}
+
+func (v typedPluginsConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var plugins PluginsValue
+	var typedPlugins types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plugins"), &plugins)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("typed_plugins"), &typedPlugins)...)
+	if resp.Diagnostics.HasError() || plugins.IsNull() || plugins.IsUnknown() || typedPlugins.IsNull() || typedPlugins.IsUnknown() {
+		return
+	}
+
+	var configuredPlugins map[string]interface{}
+	if json.Unmarshal([]byte(plugins.ValueString()), &configuredPlugins) != nil {
+		// Reported by the validator of the plugins attribute
+		return
+	}
+
+	for attributeName, value := range typedPlugins.Attributes() {
+		if value.IsNull() {
+			continue
+		}
+
+		name := typedPluginName(attributeName)
+		if _, ok := configuredPlugins[name]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("typed_plugins").AtName(attributeName),
+				"Conflicting Plugin Configuration",
+				"The "+name+" plugin is configured in both the plugins JSON and the typed_plugins attribute. Configure it in only one of them.",
+			)
+		}
+	}
+}
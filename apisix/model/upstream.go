@@ -5,7 +5,9 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -37,17 +39,21 @@ type UpstreamResourceModel struct {
 	TLSClientCertID types.String               `tfsdk:"tls_client_cert_id"`
 	Checks          *UpstreamChecksType        `tfsdk:"checks"`
 	Nodes           *[]UpstreamNodeType        `tfsdk:"nodes"`
+	Timeouts        timeouts.Value             `tfsdk:"timeouts"`
 }
 
 var UpstreamSchema = schema.Schema{
 	Description: "Manages APISIX Upstreams.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the upstream.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the upstream. Generated by APISIX unless configured.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators(),
 		},
 		"type": schema.StringAttribute{
 			MarkdownDescription: "Load balancing algorithm to be used, and the default value is `roundrobin`.\n" +
@@ -137,6 +143,14 @@ var UpstreamSchema = schema.Schema{
 		"checks": UpstreamChecksSchemaAttribute,
 		"nodes":  UpstreamNodesSchemaAttribute,
 	},
+	Blocks: map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	},
+}
+
+// UpstreamDataSourceSchema returns the schema of the upstream data source.
+func UpstreamDataSourceSchema() (dsschema.Schema, error) {
+	return DataSourceSchemaFromResourceSchema(UpstreamSchema, "id", "Retrieves an APISIX upstream by its identifier.")
 }
 
 func UpstreamFromTerraformToAPI(ctx context.Context, terraformDataModel *UpstreamResourceModel) (apiDataModel api_client.Upstream, labelsDiag diag.Diagnostics) {
@@ -190,6 +204,7 @@ func UpstreamFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Up
 	terraformDataModel.KeepalivePool = UpstreamKeepAlivePoolFromAPIToTerraform(apiDataModel.KeepalivePool)
 	terraformDataModel.Checks = UpstreamChecksFromApiToTerraform(ctx, apiDataModel.Checks)
 	terraformDataModel.Nodes = UpstreamNodesFromApiToTerraform(ctx, apiDataModel.Nodes)
+	terraformDataModel.Timeouts = nullTimeouts()
 
 	tflog.Debug(ctx, "Result of UpstreamFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"context"
+
+	"github.com/holubovskyi/apisix-client-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UpstreamInlineType maps the inline upstream of the route or service.
+type UpstreamInlineType struct {
+	Type            types.String               `tfsdk:"type"`
+	ServiceName     types.String               `tfsdk:"service_name"`
+	DiscoveryType   types.String               `tfsdk:"discovery_type"`
+	Timeout         *TimeoutType               `tfsdk:"timeout"`
+	Name            types.String               `tfsdk:"name"`
+	Desc            types.String               `tfsdk:"desc"`
+	PassHost        types.String               `tfsdk:"pass_host"`
+	Scheme          types.String               `tfsdk:"scheme"`
+	Retries         types.Int64                `tfsdk:"retries"`
+	RetryTimeout    types.Int64                `tfsdk:"retry_timeout"`
+	Labels          types.Map                  `tfsdk:"labels"`
+	UpstreamHost    types.String               `tfsdk:"upstream_host"`
+	HashOn          types.String               `tfsdk:"hash_on"`
+	Key             types.String               `tfsdk:"key"`
+	KeepalivePool   *UpstreamKeepAlivePoolType `tfsdk:"keepalive_pool"`
+	TLSClientCertID types.String               `tfsdk:"tls_client_cert_id"`
+	Checks          *UpstreamChecksType        `tfsdk:"checks"`
+	Nodes           *[]UpstreamNodeType        `tfsdk:"nodes"`
+}
+
+var UpstreamInlineSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "Inline Upstream configuration. Can be used instead of the `upstream_id`.",
+	Optional:            true,
+	Attributes:          upstreamInlineAttributes(),
+	Validators: []validator.Object{
+		upstreamInlineValidator{},
+	},
+}
+
+// upstreamInlineAttributes returns the upstream resource attributes without the identifier.
+func upstreamInlineAttributes() map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(UpstreamSchema.Attributes))
+
+	for name, attribute := range UpstreamSchema.Attributes {
+		if name == "id" {
+			continue
+		}
+		attributes[name] = attribute
+	}
+
+	// Changing the service name of the inline upstream doesn't require the replacement
+	serviceName := attributes["service_name"].(schema.StringAttribute)
+	serviceName.PlanModifiers = nil
+	attributes["service_name"] = serviceName
+
+	return attributes
+}
+
+var _ validator.Object = upstreamInlineValidator{}
+
+// upstreamInlineValidator applies the rules of the apisix_upstream config validators to the inline upstream:
+// exactly one of nodes and service_name is required, and service_name and discovery_type go together.
+type upstreamInlineValidator struct{}
+
+func (v upstreamInlineValidator) Description(_ context.Context) string {
+	return "exactly one of nodes and service_name must be configured, and service_name requires discovery_type"
+}
+
+func (v upstreamInlineValidator) MarkdownDescription(_ context.Context) string {
+	return "exactly one of `nodes` and `service_name` must be configured, and `service_name` requires `discovery_type`"
+}
+
+func (v upstreamInlineValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	nodes := attributes["nodes"]
+	serviceName := attributes["service_name"]
+	discoveryType := attributes["discovery_type"]
+
+	// The rules are checked once the values are known
+	if nodes.IsUnknown() || serviceName.IsUnknown() || discoveryType.IsUnknown() {
+		return
+	}
+
+	if nodes.IsNull() == serviceName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Combination",
+			"Exactly one of "+req.Path.AtName("nodes").String()+" and "+req.Path.AtName("service_name").String()+" must be configured.",
+		)
+		return
+	}
+
+	if serviceName.IsNull() != discoveryType.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Combination",
+			req.Path.AtName("service_name").String()+" and "+req.Path.AtName("discovery_type").String()+" must be configured together.",
+		)
+	}
+}
+
+func UpstreamInlineFromTerraformToAPI(ctx context.Context, terraformDataModel *UpstreamInlineType) (apiDataModel *api_client.Upstream, diags diag.Diagnostics) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	upstream, diags := UpstreamFromTerraformToAPI(ctx, &UpstreamResourceModel{
+		ID:              types.StringNull(),
+		Type:            terraformDataModel.Type,
+		ServiceName:     terraformDataModel.ServiceName,
+		DiscoveryType:   terraformDataModel.DiscoveryType,
+		Timeout:         terraformDataModel.Timeout,
+		Name:            terraformDataModel.Name,
+		Desc:            terraformDataModel.Desc,
+		PassHost:        terraformDataModel.PassHost,
+		Scheme:          terraformDataModel.Scheme,
+		Retries:         terraformDataModel.Retries,
+		RetryTimeout:    terraformDataModel.RetryTimeout,
+		Labels:          terraformDataModel.Labels,
+		UpstreamHost:    terraformDataModel.UpstreamHost,
+		HashOn:          terraformDataModel.HashOn,
+		Key:             terraformDataModel.Key,
+		KeepalivePool:   terraformDataModel.KeepalivePool,
+		TLSClientCertID: terraformDataModel.TLSClientCertID,
+		Checks:          terraformDataModel.Checks,
+		Nodes:           terraformDataModel.Nodes,
+	})
+
+	return &upstream, diags
+}
+
+func UpstreamInlineFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Upstream) (terraformDataModel *UpstreamInlineType, diags diag.Diagnostics) {
+	if apiDataModel == nil {
+		return
+	}
+
+	upstream, diags := UpstreamFromApiToTerraform(ctx, apiDataModel)
+
+	return &UpstreamInlineType{
+		Type:            upstream.Type,
+		ServiceName:     upstream.ServiceName,
+		DiscoveryType:   upstream.DiscoveryType,
+		Timeout:         upstream.Timeout,
+		Name:            upstream.Name,
+		Desc:            upstream.Desc,
+		PassHost:        upstream.PassHost,
+		Scheme:          upstream.Scheme,
+		Retries:         upstream.Retries,
+		RetryTimeout:    upstream.RetryTimeout,
+		Labels:          upstream.Labels,
+		UpstreamHost:    upstream.UpstreamHost,
+		HashOn:          upstream.HashOn,
+		Key:             upstream.Key,
+		KeepalivePool:   upstream.KeepalivePool,
+		TLSClientCertID: upstream.TLSClientCertID,
+		Checks:          upstream.Checks,
+		Nodes:           upstream.Nodes,
+	}, diags
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"context"
+
+	"github.com/holubovskyi/apisix-client-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// UpstreamsDataSourceModel maps the upstreams data source schema data.
+type UpstreamsDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Labels     types.Map    `tfsdk:"labels"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Upstreams  types.List   `tfsdk:"upstreams"`
+}
+
+// UpstreamsDataSourceSchema returns the schema of the upstreams data source.
+func UpstreamsDataSourceSchema() (schema.Schema, error) {
+	upstreams, err := DataSourceListAttributeFromResourceSchema(UpstreamSchema, "List of the matching upstreams.")
+	if err != nil {
+		return schema.Schema{}, err
+	}
+
+	return schema.Schema{
+		Description: "Lists APISIX upstreams, optionally filtered by labels or name prefix.",
+		Attributes: map[string]schema.Attribute{
+			"id":          ListIDSchemaAttribute,
+			"labels":      LabelsFilterSchemaAttribute,
+			"name_prefix": NamePrefixFilterSchemaAttribute,
+			"upstreams":   upstreams,
+		},
+	}, nil
+}
+
+func UpstreamsFromApiToTerraform(ctx context.Context, apiDataModel []api_client.Upstream, filter *UpstreamsDataSourceModel) (terraformDataModel []UpstreamResourceModel, diags diag.Diagnostics) {
+	selector, diags := labelSelector(ctx, filter.Labels)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	terraformDataModel = []UpstreamResourceModel{}
+
+	for i := range apiDataModel {
+		upstream := &apiDataModel[i]
+
+		if !matchesLabels(upstream.Labels, selector) ||
+			!matchesPrefix(upstream.Name, filter.NamePrefix) {
+			continue
+		}
+
+		upstreamModel, labelsDiag := UpstreamFromApiToTerraform(ctx, upstream)
+		diags.Append(labelsDiag...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		terraformDataModel = append(terraformDataModel, upstreamModel)
+	}
+
+	tflog.Debug(ctx, "Result of the UpstreamsFromApiToTerraform", map[string]any{
+		"Count": len(terraformDataModel),
+	})
+
+	return terraformDataModel, diags
+}
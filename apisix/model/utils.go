@@ -4,14 +4,15 @@ import (
 	"context"
 	"encoding/json"
 
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-func PluginsStringToJson(ctx context.Context, str types.String) (jsonPointer *map[string]interface{}) {
+func PluginsStringToJson(ctx context.Context, str PluginsValue, attributePath path.Path) (jsonPointer *map[string]interface{}, diags diag.Diagnostics) {
 
 	if str.IsNull() {
-		return nil
+		return nil, diags
 	}
 
 	var result map[string]interface{}
@@ -23,33 +24,43 @@ func PluginsStringToJson(ctx context.Context, str types.String) (jsonPointer *ma
 			"Error converting plugins to json": err,
 			"Input string is":                  str.ValueString(),
 		})
-		panic(err)
+		diags.AddAttributeError(
+			attributePath,
+			"Error Converting Plugins",
+			"Could not convert plugins to JSON object: "+err.Error(),
+		)
+		return nil, diags
 	}
 
-	return &result
+	return &result, diags
 }
 
-func PluginsFromJsonToString(ctx context.Context, jsonPointer *map[string]interface{}) (str types.String) {
+func PluginsFromJsonToString(ctx context.Context, jsonPointer *map[string]interface{}, attributePath path.Path) (str PluginsValue, diags diag.Diagnostics) {
 	if jsonPointer == nil {
-		return types.StringNull()
+		return NewPluginsNull(), diags
 	}
 
 	data, err := json.Marshal(jsonPointer)
 	if err != nil {
 		tflog.Error(ctx, "Error converting plugins to terraform values")
-		panic(err)
+		diags.AddAttributeError(
+			attributePath,
+			"Error Converting Plugins",
+			"Could not convert plugins returned by APISIX to JSON string: "+err.Error(),
+		)
+		return NewPluginsNull(), diags
 	}
 
 	jsonStr := string(data)
 
-	return types.StringValue(jsonStr)
+	return NewPluginsValue(jsonStr), diags
 
 }
 
-func VarsStringToJson(ctx context.Context, str types.String) (jsonPointer *[]interface{}) {
+func VarsStringToJson(ctx context.Context, str JSONValue, attributePath path.Path) (jsonPointer *[]interface{}, diags diag.Diagnostics) {
 
 	if str.IsNull() {
-		return nil
+		return nil, diags
 	}
 
 	var result []interface{}
@@ -61,25 +72,35 @@ func VarsStringToJson(ctx context.Context, str types.String) (jsonPointer *[]int
 			"Error converting vars to json": err,
 			"Input string is":               str.ValueString(),
 		})
-		panic(err)
+		diags.AddAttributeError(
+			attributePath,
+			"Error Converting Vars",
+			"Could not convert vars to JSON array: "+err.Error(),
+		)
+		return nil, diags
 	}
 
-	return &result
+	return &result, diags
 }
 
-func VarsFromJsonToString(ctx context.Context, jsonPointer *[]interface{}) (str types.String) {
+func VarsFromJsonToString(ctx context.Context, jsonPointer *[]interface{}, attributePath path.Path) (str JSONValue, diags diag.Diagnostics) {
 	if jsonPointer == nil {
-		return types.StringNull()
+		return NewJSONNull(), diags
 	}
 
 	data, err := json.Marshal(jsonPointer)
 	if err != nil {
 		tflog.Error(ctx, "Error converting vars to terraform values")
-		panic(err)
+		diags.AddAttributeError(
+			attributePath,
+			"Error Converting Vars",
+			"Could not convert vars returned by APISIX to JSON string: "+err.Error(),
+		)
+		return NewJSONNull(), diags
 	}
 
 	jsonStr := string(data)
 
-	return types.StringValue(jsonStr)
+	return NewJSONValue(jsonStr), diags
 
 }
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestPluginsStringToJsonAttributePath(t *testing.T) {
+	attributePath := path.Root("upstream").AtName("plugins")
+
+	_, diags := PluginsStringToJson(context.Background(), NewPluginsValue(`{"prometheus":`), attributePath)
+	if !diags.HasError() {
+		t.Fatal("expected an error converting the invalid plugins")
+	}
+	if diags[0].Summary() != "Error Converting Plugins" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	withPath, ok := diags[0].(interface{ Path() path.Path })
+	if !ok || !withPath.Path().Equal(attributePath) {
+		t.Fatalf("expected the error on %s, got %v", attributePath, diags[0])
+	}
+}
+
+func TestPluginsFromJsonToStringError(t *testing.T) {
+	plugins := map[string]interface{}{
+		"limit-count": map[string]interface{}{"count": math.Inf(1)},
+	}
+
+	value, diags := PluginsFromJsonToString(context.Background(), &plugins, path.Root("plugins"))
+	if !diags.HasError() {
+		t.Fatal("expected an error instead of a panic converting the invalid plugins")
+	}
+	if !value.IsNull() {
+		t.Fatalf("expected the null plugins, got %s", value)
+	}
+}
+
+func TestVarsFromJsonToString(t *testing.T) {
+	vars := []interface{}{[]interface{}{"arg_name", "==", "json"}}
+
+	value, diags := VarsFromJsonToString(context.Background(), &vars, path.Root("vars"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if value.ValueString() != `[["arg_name","==","json"]]` {
+		t.Fatalf("unexpected vars %s", value.ValueString())
+	}
+}
@@ -0,0 +1,40 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPluginConfigDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_plugin_config" "test" {
+	id   = "ds001"
+	desc = "Example of the plugin config data source"
+	plugins = jsonencode(
+		{
+			prometheus = {
+				prefer_name = false
+			}
+		}
+	)
+}
+
+data "apisix_plugin_config" "test" {
+	id = apisix_plugin_config.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_plugin_config.test", "id", "apisix_plugin_config.test", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_plugin_config.test", "desc", "apisix_plugin_config.test", "desc"),
+				),
+			},
+		},
+	})
+}
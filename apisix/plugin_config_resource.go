@@ -4,21 +4,22 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &pluginConfigResource{}
-	_ resource.ResourceWithConfigure   = &pluginConfigResource{}
-	_ resource.ResourceWithImportState = &pluginConfigResource{}
+	_ resource.Resource                     = &pluginConfigResource{}
+	_ resource.ResourceWithConfigure        = &pluginConfigResource{}
+	_ resource.ResourceWithImportState      = &pluginConfigResource{}
+	_ resource.ResourceWithConfigValidators = &pluginConfigResource{}
+	_ resource.ResourceWithModifyPlan       = &pluginConfigResource{}
 )
 
 // NewPluginConfigResource is a helper function to simplify the provider implementation.
@@ -28,7 +29,7 @@ func NewPluginConfigResource() resource.Resource {
 
 // pluginConfigResource is the resource implementation.
 type pluginConfigResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +42,34 @@ func (r *pluginConfigResource) Schema(_ context.Context, _ resource.SchemaReques
 	resp.Schema = model.PluginConfigSchema
 }
 
+// Validate Config
+func (r *pluginConfigResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("plugins"),
+			path.MatchRoot("typed_plugins"),
+		),
+		model.TypedPluginsConfigValidator(),
+	}
+}
+
+// ModifyPlan validates the plugins against the plugin schemas of APISIX.
+func (r *pluginConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validatePlugins(ctx, r.client, req.Plan, admin.SubsystemHTTP, admin.SchemaTypeRoute)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *pluginConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,11 +88,26 @@ func (r *pluginConfigResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	newPluginConfigRequest := model.PluginConfigFromTerraformToApi(ctx, &plan)
+	newPluginConfigRequest, diags := model.PluginConfigFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create new plugin config
-	newPluginConfigResponse, err := r.client.CreatePluginConfig(plan.ID.ValueString(), newPluginConfigRequest)
+	newPluginConfigResponse, err := client.CreatePluginConfig(plan.ID.ValueString(), newPluginConfigRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Plugin Config",
@@ -85,11 +117,25 @@ func (r *pluginConfigResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	newState := model.PluginConfigFromApiToTerraform(ctx, newPluginConfigResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.PluginConfigFromApiToTerraform(ctx, newPluginConfigResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -109,9 +155,27 @@ func (r *pluginConfigResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed plugin config from the APISIX
-	pluginConfigStateResponse, err := r.client.GetPluginConfig(state.ID.ValueString())
+	pluginConfigStateResponse, err := client.GetPluginConfig(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The plugin config is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Plugin Config",
 			"Could not read APISIX Plugin Config by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -120,11 +184,25 @@ func (r *pluginConfigResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Overwrite with refreshed state
-	newState := model.PluginConfigFromApiToTerraform(ctx, pluginConfigStateResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+	newState, diags := model.PluginConfigFromApiToTerraform(ctx, pluginConfigStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, state.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,11 +222,26 @@ func (r *pluginConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	updatePluginConfigRequest := model.PluginConfigFromTerraformToApi(ctx, &plan)
+	updatePluginConfigRequest, diags := model.PluginConfigFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update existing plugin config
-	_, err := r.client.UpdatePluginConfig(plan.ID.ValueString(), updatePluginConfigRequest)
+	_, err := client.UpdatePluginConfig(plan.ID.ValueString(), updatePluginConfigRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Plugin Config",
@@ -158,7 +251,7 @@ func (r *pluginConfigResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Fetch updated rule
-	updatedPluginConfig, err := r.client.GetPluginConfig(plan.ID.ValueString())
+	updatedPluginConfig, err := client.GetPluginConfig(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Plugin Config",
@@ -167,11 +260,25 @@ func (r *pluginConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	newState := model.PluginConfigFromApiToTerraform(ctx, updatedPluginConfig)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.PluginConfigFromApiToTerraform(ctx, updatedPluginConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,8 +298,18 @@ func (r *pluginConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete the plugin config
-	err := r.client.DeletePluginConfig(state.ID.ValueString())
+	err := client.DeletePluginConfig(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Plugin Config",
@@ -205,6 +322,15 @@ func (r *pluginConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 // Import resource into state
 func (r *pluginConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the plugin config importing")
+	// Validate the import ID and check the plugin config exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Plugin Config", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetPluginConfig(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,287 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &pluginMetadataResource{}
+	_ resource.ResourceWithConfigure   = &pluginMetadataResource{}
+	_ resource.ResourceWithImportState = &pluginMetadataResource{}
+)
+
+// NewPluginMetadataResource is a helper function to simplify the provider implementation.
+func NewPluginMetadataResource() resource.Resource {
+	return &pluginMetadataResource{}
+}
+
+// pluginMetadataResource is the resource implementation.
+type pluginMetadataResource struct {
+	client *admin.Client
+}
+
+// Metadata returns the resource type name.
+func (r *pluginMetadataResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin_metadata"
+}
+
+// Schema defines the schema for the resource.
+func (r *pluginMetadataResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.PluginMetadataSchema
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *pluginMetadataResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create a new resource.
+func (r *pluginMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Start of the plugin metadata resource creation")
+	// Retrieve values from plan
+	var plan model.PluginMetadataResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Generate API request body from plan
+	newPluginMetadataRequest, diags := model.PluginMetadataFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new plugin metadata
+	newPluginMetadataResponse, err := client.CreatePluginMetadata(plan.PluginName.ValueString(), newPluginMetadataRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Plugin Metadata",
+			"Could not create Plugin Metadata, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	newState, diags := model.PluginMetadataFromApiToTerraform(ctx, plan.PluginName.ValueString(), newPluginMetadataResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *pluginMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the plugin metadata resource read")
+	// Get current state
+	var state model.PluginMetadataResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Get refreshed plugin metadata from the APISIX
+	pluginMetadataStateResponse, err := client.GetPluginMetadata(state.ID.ValueString())
+	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The plugin metadata is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Plugin Metadata",
+			"Could not read APISIX Plugin Metadata of the plugin "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite with refreshed state
+	newState, diags := model.PluginMetadataFromApiToTerraform(ctx, state.ID.ValueString(), pluginMetadataStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update the resource.
+func (r *pluginMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Start of the plugin metadata resource update")
+	// Retrieve values from plan
+	var plan model.PluginMetadataResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Generate API request body from plan
+	updatePluginMetadataRequest, diags := model.PluginMetadataFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing plugin metadata
+	_, err := client.UpdatePluginMetadata(plan.PluginName.ValueString(), updatePluginMetadataRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating APISIX Plugin Metadata",
+			"Could not update Plugin Metadata, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Fetch updated plugin metadata
+	updatedPluginMetadata, err := client.GetPluginMetadata(plan.PluginName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Plugin Metadata",
+			"Could not read APISIX Plugin Metadata of the plugin "+plan.PluginName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	newState, diags := model.PluginMetadataFromApiToTerraform(ctx, plan.PluginName.ValueString(), updatedPluginMetadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource.
+func (r *pluginMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Start of the plugin metadata resource delete")
+	// Get current state
+	var state model.PluginMetadataResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Delete the plugin metadata
+	err := client.DeletePluginMetadata(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting APISIX Plugin Metadata",
+			"Could not delete Plugin Metadata, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Import resource into state
+func (r *pluginMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Start of the plugin metadata importing")
+	// Validate the import ID and check the plugin metadata exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Plugin Metadata", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetPluginMetadata(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve import ID, which is the plugin name, and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,65 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPluginMetadataResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_plugin_metadata" "test" {
+	plugin_name = "http-logger"
+	metadata = jsonencode(
+		{
+			log_format = {
+				host        = "$host"
+				"@timestamp" = "$time_iso8601"
+			}
+		}
+	)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// NOTE: State value checking is only necessary for Computed attributes,
+					//       as the testing framework will automatically return test failures
+					//       for configured attributes that mismatch the saved state.
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttr("apisix_plugin_metadata.test", "id", "http-logger"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_plugin_metadata.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_plugin_metadata" "test" {
+	plugin_name = "http-logger"
+	metadata = jsonencode(
+		{
+			log_format = {
+				host        = "$host"
+				"@timestamp" = "$time_iso8601"
+				client_ip   = "$remote_addr"
+			}
+		}
+	)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_plugin_metadata.test", "id", "http-logger"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// validatePlugins validates the plugins JSON of the planned resource against the plugin schemas
+// fetched from APISIX, so the invalid plugins are reported during the plan instead of the apply.
+// The typed plugins are validated by their Terraform schema.
+func validatePlugins(ctx context.Context, client *admin.Client, plan tfsdk.Plan, subsystem string, schemaType string) (diags diag.Diagnostics) {
+	// Nothing to validate on the destroy or before the provider is configured
+	if plan.Raw.IsNull() || client == nil {
+		return diags
+	}
+
+	var plugins model.PluginsValue
+	diags.Append(plan.GetAttribute(ctx, path.Root("plugins"), &plugins)...)
+	if diags.HasError() || plugins.IsNull() || plugins.IsUnknown() {
+		return diags
+	}
+
+	var configs map[string]interface{}
+	if json.Unmarshal([]byte(plugins.ValueString()), &configs) != nil {
+		// Reported by the validator of the plugins attribute
+		return diags
+	}
+
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	client = client.WithContext(ctx)
+
+	// The plugins can't be listed e.g. when the Admin API is behind the proxy allowing only the object endpoints
+	err := client.LoadPluginSchemas()
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("plugins"),
+			"Plugins Not Validated",
+			"Could not list the plugins enabled in APISIX, so the plugins are not validated during the plan: "+err.Error(),
+		)
+		return diags
+	}
+
+	for _, name := range names {
+		validationErr, err := client.ValidatePlugin(name, subsystem, schemaType, configs[name])
+		if err != nil {
+			diags.AddAttributeWarning(
+				path.Root("plugins"),
+				"Plugin Not Validated",
+				"Could not get the schema of the "+name+" plugin from APISIX, so the plugin is not validated during the plan: "+err.Error(),
+			)
+			continue
+		}
+
+		var unknownPluginErr *admin.UnknownPluginError
+		if errors.As(validationErr, &unknownPluginErr) {
+			diags.AddAttributeError(
+				path.Root("plugins"),
+				"Unknown Plugin",
+				"The "+name+" plugin is not enabled in APISIX. Check the name of the plugin and the plugins enabled in the APISIX configuration.",
+			)
+			continue
+		}
+
+		if validationErr != nil {
+			diags.AddAttributeError(
+				path.Root("plugins"),
+				"Invalid Plugin Configuration",
+				"The configuration of the "+name+" plugin doesn't match the plugin schema of APISIX:\n"+validationErr.Error(),
+			)
+		}
+	}
+
+	return diags
+}
+
+// registerPluginDefaults registers the default values of the plugins returned by APISIX, declared in the
+// plugin schemas, so the plugin fields set by APISIX are not reported as a difference from the configuration.
+// The plugins whose schema can't be fetched fall back to the known defaults of the common plugins.
+func registerPluginDefaults(ctx context.Context, client *admin.Client, plugins model.PluginsValue, subsystem string, schemaType string) {
+	if plugins.IsNull() || plugins.IsUnknown() {
+		return
+	}
+
+	var configs map[string]interface{}
+	if json.Unmarshal([]byte(plugins.ValueString()), &configs) != nil {
+		return
+	}
+
+	for name := range configs {
+		defaults, err := client.PluginDefaults(name, subsystem, schemaType)
+		if err != nil {
+			tflog.Debug(ctx, "Could not get the plugin schema defaults, falling back to the known defaults", map[string]any{
+				"Plugin": name,
+				"Error":  err,
+			})
+			continue
+		}
+
+		model.RegisterPluginDefaults(name, defaults)
+	}
+}
@@ -0,0 +1,96 @@
+package apisix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestValidatePluginsWarnings(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		handler http.HandlerFunc
+		summary string
+	}{
+		"plugins not listed": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			summary: "Plugins Not Validated",
+		},
+		"schema not compiled": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/apisix/admin/plugins/list":
+					_, _ = w.Write([]byte(`["limit-count"]`))
+				default:
+					_, _ = w.Write([]byte(`{"type": 1}`))
+				}
+			},
+			summary: "Plugin Not Validated",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				testCase.handler(w, r)
+			}))
+			defer server.Close()
+
+			endpoint := server.URL
+			apiKey := "test"
+			apiClient, err := api_client.NewClient(&endpoint, &apiKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := admin.NewClient(apiClient)
+			client.EnablePluginValidation()
+			if requests != 0 {
+				t.Fatalf("expected no requests before the validation, got %d", requests)
+			}
+
+			plan := tfsdk.Plan{
+				Schema: model.RouteSchema,
+				Raw:    tftypes.NewValue(model.RouteSchema.Type().TerraformType(ctx), nil),
+			}
+			diags := plan.SetAttribute(ctx, path.Root("plugins"), model.NewPluginsValue(`{"limit-count": {"count": 1}}`))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			diags = validatePlugins(ctx, client, plan, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+			assertWarning(t, diags, testCase.summary)
+
+			// The failure is cached, so the next resources don't request APISIX again
+			requestsBefore := requests
+			diags = validatePlugins(ctx, client, plan, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+			assertWarning(t, diags, testCase.summary)
+			if requests != requestsBefore {
+				t.Fatalf("expected the failure to be cached, got %d more requests", requests-requestsBefore)
+			}
+		})
+	}
+}
+
+func assertWarning(t *testing.T, diags diag.Diagnostics, summary string) {
+	t.Helper()
+
+	if diags.HasError() || diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != summary {
+		t.Fatalf("expected the %q warning, got %v", summary, diags)
+	}
+}
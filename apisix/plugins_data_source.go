@@ -0,0 +1,143 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &pluginsDataSource{}
+	_ datasource.DataSourceWithConfigure = &pluginsDataSource{}
+)
+
+// NewPluginsDataSource is a helper function to simplify the provider implementation.
+func NewPluginsDataSource() datasource.DataSource {
+	return &pluginsDataSource{}
+}
+
+// pluginsDataSource is the data source implementation.
+type pluginsDataSource struct {
+	client *admin.Client
+}
+
+// Metadata returns the data source type name.
+func (d *pluginsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugins"
+}
+
+// Schema defines the schema for the data source.
+func (d *pluginsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = model.PluginsDataSourceSchema
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pluginsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pluginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the plugins data source read")
+	// Get the plugins filters
+	var state model.PluginsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := "all"
+	subsystems := []string{admin.SubsystemHTTP, admin.SubsystemStream}
+	if !state.Type.IsNull() {
+		id = state.Type.ValueString()
+		subsystems = []string{state.Type.ValueString()}
+	}
+
+	client := d.client.WithContext(ctx)
+	state.Plugins = []model.PluginDataSourceModel{}
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, subsystem := range subsystems {
+		// Get the plugins enabled in the APISIX of the subsystem
+		pluginNames, err := client.ListPlugins(subsystem)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading APISIX Plugins",
+				"Could not list APISIX "+subsystem+" plugins: "+err.Error(),
+			)
+			return
+		}
+		sort.Strings(pluginNames)
+
+		// The priorities are only returned along with all the plugin attributes by the deprecated endpoint,
+		// so they are left null once APISIX doesn't serve it
+		attributes, err := client.ListPluginAttributes(subsystem)
+		if err != nil {
+			tflog.Warn(ctx, "Could not read the priorities of the APISIX plugins", map[string]any{
+				"subsystem": subsystem,
+				"error":     err.Error(),
+			})
+		}
+
+		for _, name := range pluginNames {
+			schema, err := client.GetPluginSchema(name, subsystem, admin.SchemaTypeRoute)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading APISIX Plugins",
+					"Could not read the schema of the APISIX "+subsystem+" plugin "+name+": "+err.Error(),
+				)
+				return
+			}
+
+			state.Plugins = append(state.Plugins, model.PluginDataSourceModel{
+				Name:     types.StringValue(name),
+				Priority: types.Int64PointerValue(attributes[name].Priority),
+				Type:     types.StringValue(subsystem),
+				Schema:   model.NewJSONValue(string(schema)),
+			})
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	state.ID = types.StringValue(id)
+	state.Names, diags = types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
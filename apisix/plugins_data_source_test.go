@@ -0,0 +1,47 @@
+package apisix
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPluginsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "apisix_plugins" "all" {}
+
+data "apisix_plugins" "stream" {
+	type = "stream"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.apisix_plugins.all", "names.*", "limit-count"),
+					resource.TestCheckTypeSetElemAttr("data.apisix_plugins.all", "names.*", "mqtt-proxy"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.apisix_plugins.all", "plugins.*", map[string]string{
+						"name":     "limit-count",
+						"type":     "http",
+						"priority": "1000",
+					}),
+					resource.TestCheckResourceAttrSet("data.apisix_plugins.all", "plugins.0.schema"),
+					// Verify the stream plugins come with the schemas of the stream subsystem
+					resource.TestMatchTypeSetElemNestedAttrs("data.apisix_plugins.stream", "plugins.*", map[string]*regexp.Regexp{
+						"name":   regexp.MustCompile(`^mqtt-proxy$`),
+						"schema": regexp.MustCompile(`"protocol_name"`),
+					}),
+					// Verify the type filter
+					resource.TestCheckResourceAttr("data.apisix_plugins.stream", "plugins.0.type", "stream"),
+					// Verify the identifiers derived from the type filter
+					resource.TestCheckResourceAttr("data.apisix_plugins.all", "id", "all"),
+					resource.TestCheckResourceAttr("data.apisix_plugins.stream", "id", "stream"),
+					resource.TestCheckNoResourceAttr("data.apisix_plugins.stream", "plugins.4.name"),
+				),
+			},
+		},
+	})
+}
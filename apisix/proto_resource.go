@@ -0,0 +1,390 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &protoResource{}
+	_ resource.ResourceWithConfigure        = &protoResource{}
+	_ resource.ResourceWithImportState      = &protoResource{}
+	_ resource.ResourceWithConfigValidators = &protoResource{}
+	_ resource.ResourceWithModifyPlan       = &protoResource{}
+)
+
+// NewProtoResource is a helper function to simplify the provider implementation.
+func NewProtoResource() resource.Resource {
+	return &protoResource{}
+}
+
+// protoResource is the resource implementation.
+type protoResource struct {
+	client *admin.Client
+}
+
+// Metadata returns the resource type name.
+func (r *protoResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proto"
+}
+
+// Schema defines the schema for the resource.
+func (r *protoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.ProtoSchema
+}
+
+// Validate Config
+func (r *protoResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("content"),
+			path.MatchRoot("content_file"),
+		),
+	}
+}
+
+// ModifyPlan tracks the content by its checksum. The proto file is only compared during the plan and
+// uploaded during the apply, as the known content would make the apply fail once the file changes in between.
+func (r *protoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan model.ProtoResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contentSHA256 := types.StringUnknown()
+
+	if plan.ContentFile.IsNull() {
+		// The configured content is known during the plan
+		if !plan.Content.IsUnknown() {
+			contentSHA256 = types.StringValue(model.ProtoContentSHA256(plan.Content.ValueString()))
+		}
+	} else if plan.ContentFile.IsUnknown() {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("content"), types.StringUnknown())
+		resp.Diagnostics.Append(diags...)
+	} else {
+		content, diags := model.ProtoContentFromFile(ctx, plan.ContentFile)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var stateContentSHA256 types.String
+		if !req.State.Raw.IsNull() {
+			diags = req.State.GetAttribute(ctx, path.Root("content_sha256"), &stateContentSHA256)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// Keep the uploaded content of the unchanged file, otherwise the file is read again during the apply
+		if stateContentSHA256.ValueString() == model.ProtoContentSHA256(content.ValueString()) {
+			contentSHA256 = stateContentSHA256
+		} else {
+			diags = resp.Plan.SetAttribute(ctx, path.Root("content"), types.StringUnknown())
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), contentSHA256)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *protoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create a new resource.
+func (r *protoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Start of the proto resource creation")
+	// Retrieve values from plan
+	var plan model.ProtoResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Upload the changed proto file
+	if plan.Content.IsUnknown() {
+		plan.Content, diags = model.ProtoContentFromFile(ctx, plan.ContentFile)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Generate API request body from plan
+	newProtoRequest, diags := model.ProtoFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fail instead of overwriting the object with the configured identifier created outside of Terraform
+	if !plan.ID.IsUnknown() {
+		resp.Diagnostics.Append(validateCreate(client, "Proto", "apisix_proto", plan.ID.ValueString(), func(client *admin.Client) error {
+			_, err := client.GetProto(plan.ID.ValueString())
+			return err
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create new proto, using the configured identifier or the one generated by APISIX
+	var newProtoResponse *admin.Proto
+	var err error
+	if plan.ID.IsUnknown() {
+		newProtoResponse, err = client.CreateProto(newProtoRequest)
+	} else {
+		newProtoResponse, err = client.UpdateProto(plan.ID.ValueString(), newProtoRequest)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Proto",
+			"Could not create Proto, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	newState, diags := model.ProtoFromApiToTerraform(ctx, newProtoResponse, plan.ContentFile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *protoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the proto resource read")
+	// Get current state
+	var state model.ProtoResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Get refreshed proto from the APISIX
+	protoStateResponse, err := client.GetProto(state.ID.ValueString())
+	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The proto is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Proto",
+			"Could not read APISIX Proto by ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite with refreshed state
+	newState, diags := model.ProtoFromApiToTerraform(ctx, protoStateResponse, state.ContentFile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update the resource.
+func (r *protoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Start of the proto resource update")
+	// Retrieve values from plan
+	var plan model.ProtoResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Upload the changed proto file
+	if plan.Content.IsUnknown() {
+		plan.Content, diags = model.ProtoContentFromFile(ctx, plan.ContentFile)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Generate API request body from plan
+	updateProtoRequest, diags := model.ProtoFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing proto
+	_, err := client.UpdateProto(plan.ID.ValueString(), updateProtoRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating APISIX Proto",
+			"Could not update Proto, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Fetch updated proto
+	updatedProto, err := client.GetProto(plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Proto",
+			"Could not read APISIX Proto by ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	newState, diags := model.ProtoFromApiToTerraform(ctx, updatedProto, plan.ContentFile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource.
+func (r *protoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Start of the proto resource delete")
+	// Get current state
+	var state model.ProtoResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Delete the proto
+	err := client.DeleteProto(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting APISIX Proto",
+			"Could not delete Proto, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Import resource into state
+func (r *protoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Start of the proto importing")
+	// Validate the import ID and check the proto exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Proto", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetProto(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
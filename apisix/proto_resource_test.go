@@ -0,0 +1,125 @@
+package apisix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestProtoResource(t *testing.T) {
+	protoFile := filepath.Join(t.TempDir(), "helloworld.proto")
+	err := os.WriteFile(protoFile, []byte(`syntax = "proto3";
+package helloworld;
+service Greeter {
+	rpc SayHello (HelloRequest) returns (HelloReply) {}
+}
+message HelloRequest {
+	string name = 1;
+}
+message HelloReply {
+	string message = 1;
+}
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContentFile := func(value string) error {
+		data, err := os.ReadFile(protoFile)
+		if err != nil {
+			return err
+		}
+		if value != string(data) {
+			return fmt.Errorf("expected content of the %s, got: %s", protoFile, value)
+		}
+		return nil
+	}
+	checkContentSHA256 := func(value string) error {
+		data, err := os.ReadFile(protoFile)
+		if err != nil {
+			return err
+		}
+		if value != model.ProtoContentSHA256(string(data)) {
+			return fmt.Errorf("expected checksum of the %s, got: %s", protoFile, value)
+		}
+		return nil
+	}
+	contentFileConfig := providerConfig + `
+resource "apisix_proto" "test" {
+	desc         = "Example of the proto"
+	content_file = "` + protoFile + `"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_proto" "test" {
+	desc    = "Example of the proto"
+	content = <<EOT
+syntax = "proto3";
+package helloworld;
+message HelloRequest {
+	string name = 1;
+}
+EOT
+	labels = {
+		"version" = "v1"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// NOTE: State value checking is only necessary for Computed attributes,
+					//       as the testing framework will automatically return test failures
+					//       for configured attributes that mismatch the saved state.
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("apisix_proto.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_proto.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: contentFileConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_proto.test", "id"),
+					resource.TestCheckResourceAttrWith("apisix_proto.test", "content", checkContentFile),
+					resource.TestCheckResourceAttrWith("apisix_proto.test", "content_sha256", checkContentSHA256),
+				),
+			},
+			// The changed proto file is uploaded
+			{
+				PreConfig: func() {
+					err := os.WriteFile(protoFile, []byte(`syntax = "proto3";
+package helloworld;
+message HelloRequest {
+	string name = 1;
+	int32 age = 2;
+}
+`), 0o600)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: contentFileConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("apisix_proto.test", "content", checkContentFile),
+					resource.TestCheckResourceAttrWith("apisix_proto.test", "content_sha256", checkContentSHA256),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -2,15 +2,26 @@ package apisix
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"terraform-provider-apisix/apisix/admin"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -39,10 +50,30 @@ type apisixProvider struct {
 
 // apisixProviderModel maps provider schema data to a Go type.
 type apisixProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	ApiKey   types.String `tfsdk:"api_key"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	Endpoints          types.List   `tfsdk:"endpoints"`
+	ApiKey             types.String `tfsdk:"api_key"`
+	CACertificate      types.String `tfsdk:"ca_certificate"`
+	ClientCertificate  types.String `tfsdk:"client_certificate"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	RetryBackoff       types.String `tfsdk:"retry_backoff"`
+	Mode               types.String `tfsdk:"mode"`
+	StandaloneFile     types.String `tfsdk:"standalone_file"`
+	ValidatePlugins    types.Bool   `tfsdk:"validate_plugins"`
 }
 
+const (
+	defaultRequestTimeout = 30 * time.Second
+	defaultMaxRetries     = 3
+	defaultRetryBackoff   = time.Second
+
+	modeAdminAPI   = "admin_api"
+	modeStandalone = "standalone"
+)
+
 // Metadata returns the provider type name.
 func (p *apisixProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "apisix"
@@ -58,10 +89,77 @@ func (p *apisixProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Description: "Endpoint for APISIX API. May also be provided via APISIX_ENDPOINT environment variable.",
 				Optional:    true,
 			},
+			"endpoints": schema.ListAttribute{
+				Description: "Endpoints of the several APISIX API nodes. The requests are spread over the endpoints in the round-robin order " +
+					"and fail over to the next endpoint on the connection errors. Conflicts with endpoint. " +
+					"May also be provided via APISIX_ENDPOINTS environment variable as a comma-separated list.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("endpoint")),
+				},
+			},
 			"api_key": schema.StringAttribute{
 				Description: "API Key for APISIX API. May also be provided via APISIX_APIKEY environment variable.",
 				Optional:    true,
 			},
+			"ca_certificate": schema.StringAttribute{
+				Description: "PEM-encoded CA certificate used to verify the APISIX API certificate. May also be provided via APISIX_CA_CERTIFICATE environment variable.",
+				Optional:    true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "PEM-encoded client certificate used for the mutual TLS authentication. May also be provided via APISIX_CLIENT_CERTIFICATE environment variable.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key of the client certificate. May also be provided via APISIX_CLIENT_KEY environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip the verification of the APISIX API certificate. May also be provided via APISIX_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of each attempt of the request to the APISIX API as a duration, e.g. `30s`. Defaults to `30s`. " +
+					"May also be provided via APISIX_REQUEST_TIMEOUT environment variable.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of retries of the `GET`, `PUT` and `DELETE` requests which failed with a connection error or a `5xx` response. Defaults to `3`. " +
+					"May also be provided via APISIX_MAX_RETRIES environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_backoff": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry as a duration, e.g. `1s`. The delay is doubled on each next retry. Defaults to `1s`. " +
+					"May also be provided via APISIX_RETRY_BACKOFF environment variable.",
+				Optional: true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "Mode of the provider. Either `admin_api`, which manages the objects with the APISIX Admin API, " +
+					"or `standalone`, which writes the objects to the declarative configuration file of APISIX running in the standalone mode. " +
+					"Defaults to `admin_api`. May also be provided via APISIX_MODE environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(modeAdminAPI, modeStandalone),
+				},
+			},
+			"standalone_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the declarative configuration file, such as `apisix.yaml`, written in the `standalone` mode. " +
+					"May also be provided via APISIX_STANDALONE_FILE environment variable.",
+				Optional: true,
+			},
+			"validate_plugins": schema.BoolAttribute{
+				MarkdownDescription: "Validate the `plugins` of the resources against the plugin schemas fetched from the APISIX Admin API during the plan, " +
+					"so the unknown plugins and the invalid plugin configuration are reported before the apply. Ignored in the `standalone` mode. " +
+					"The plugin schemas are fetched regardless, to match the plugin default values set by APISIX with the configured plugins. " +
+					"Defaults to `true`. May also be provided via APISIX_VALIDATE_PLUGINS environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -89,6 +187,15 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.Endpoints.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Unknown APISIX API Endpoints",
+			"The provider cannot create the APISIX API client as there is an unknown configuration value for the APISIX API endpoints. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the APISIX_ENDPOINTS environment variable.",
+		)
+	}
+
 	if config.ApiKey.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
@@ -98,33 +205,155 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.CACertificate.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate"),
+			"Unknown APISIX API CA Certificate",
+			"The provider cannot create the APISIX API client as there is an unknown configuration value for the APISIX API CA certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the APISIX_CA_CERTIFICATE environment variable.",
+		)
+	}
+
+	if config.ClientCertificate.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_certificate"),
+			"Unknown APISIX API Client Certificate",
+			"The provider cannot create the APISIX API client as there is an unknown configuration value for the APISIX API client certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the APISIX_CLIENT_CERTIFICATE environment variable.",
+		)
+	}
+
+	if config.ClientKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_key"),
+			"Unknown APISIX API Client Key",
+			"The provider cannot create the APISIX API client as there is an unknown configuration value for the APISIX API client key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the APISIX_CLIENT_KEY environment variable.",
+		)
+	}
+
+	if config.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown APISIX API Insecure Skip Verify",
+			"The provider cannot create the APISIX API client as there is an unknown configuration value for the insecure_skip_verify. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the APISIX_INSECURE_SKIP_VERIFY environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// In the standalone mode the objects are written to the configuration file instead of the Admin API
+	mode := os.Getenv("APISIX_MODE")
+	if !config.Mode.IsNull() && !config.Mode.IsUnknown() {
+		mode = config.Mode.ValueString()
+	}
+
+	switch mode {
+	case "", modeAdminAPI:
+	case modeStandalone:
+		p.configureStandalone(ctx, &config, resp)
+		return
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mode"),
+			"Invalid APISIX Provider Mode",
+			"The provider mode must be either "+modeAdminAPI+" or "+modeStandalone+", got: "+mode,
+		)
+		return
+	}
+
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	endpoint := os.Getenv("APISIX_ENDPOINT")
+	endpoints := []string{}
+	if value := os.Getenv("APISIX_ENDPOINTS"); value != "" {
+		endpoints = strings.Split(value, ",")
+	}
+	if value := os.Getenv("APISIX_ENDPOINT"); value != "" {
+		endpoints = []string{value}
+	}
 	apiKey := os.Getenv("APISIX_APIKEY")
+	tlsConfig := admin.TLSConfig{
+		CACertificate:     os.Getenv("APISIX_CA_CERTIFICATE"),
+		ClientCertificate: os.Getenv("APISIX_CLIENT_CERTIFICATE"),
+		ClientKey:         os.Getenv("APISIX_CLIENT_KEY"),
+	}
+
+	if value := os.Getenv("APISIX_INSECURE_SKIP_VERIFY"); value != "" {
+		insecureSkipVerify, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid APISIX_INSECURE_SKIP_VERIFY Environment Variable",
+				"The APISIX_INSECURE_SKIP_VERIFY environment variable must be a boolean value: "+err.Error(),
+			)
+			return
+		}
+		tlsConfig.InsecureSkipVerify = insecureSkipVerify
+	}
+
+	validatePlugins := true
+	if value := os.Getenv("APISIX_VALIDATE_PLUGINS"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validate_plugins"),
+				"Invalid APISIX_VALIDATE_PLUGINS Environment Variable",
+				"The APISIX_VALIDATE_PLUGINS environment variable must be a boolean value: "+err.Error(),
+			)
+			return
+		}
+		validatePlugins = parsed
+	}
+
+	if !config.ValidatePlugins.IsNull() {
+		validatePlugins = config.ValidatePlugins.ValueBool()
+	}
+
+	if !config.Endpoints.IsNull() {
+		diags = config.Endpoints.ElementsAs(ctx, &endpoints, false)
+		resp.Diagnostics.Append(diags...)
+	}
 
 	if !config.Endpoint.IsNull() {
-		endpoint = config.Endpoint.ValueString()
+		endpoints = []string{config.Endpoint.ValueString()}
 	}
 
 	if !config.ApiKey.IsNull() {
 		apiKey = config.ApiKey.ValueString()
 	}
 
+	if !config.CACertificate.IsNull() {
+		tlsConfig.CACertificate = config.CACertificate.ValueString()
+	}
+
+	if !config.ClientCertificate.IsNull() {
+		tlsConfig.ClientCertificate = config.ClientCertificate.ValueString()
+	}
+
+	if !config.ClientKey.IsNull() {
+		tlsConfig.ClientKey = config.ClientKey.ValueString()
+	}
+
+	if !config.InsecureSkipVerify.IsNull() {
+		tlsConfig.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+
+	retryConfig, diags := retryConfigFromProviderModel(&config)
+	resp.Diagnostics.Append(diags...)
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if endpoint == "" {
+	if len(endpoints) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
 			"Missing APISIX API Endpoint",
 			"The provider cannot create the APISIX API client as there is a missing or empty value for the APISIX API Endpoint. "+
-				"Set the endpoint value in the configuration or use the APISIX_ENDPOINT environment variable. "+
+				"Set the endpoint or endpoints value in the configuration or use the APISIX_ENDPOINT or APISIX_ENDPOINTS environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -143,7 +372,29 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "apisix_endpoint", endpoint)
+	// Use the dedicated HTTP client with the TLS settings of the Admin API
+	transport, err := admin.NewTransport(tlsConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid APISIX API TLS Configuration",
+			"The provider cannot create the APISIX API client as the TLS configuration is invalid: "+err.Error(),
+		)
+		return
+	}
+
+	// Spread the requests over the endpoints
+	endpointsTransport, err := admin.NewEndpointsTransport(endpoints, transport)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Invalid APISIX API Endpoint",
+			"The provider cannot create the APISIX API client as the endpoint is invalid: "+err.Error(),
+		)
+		return
+	}
+	endpoint := endpointsTransport.Endpoint()
+
+	ctx = tflog.SetField(ctx, "apisix_endpoints", endpoints)
 	ctx = tflog.SetField(ctx, "apisix_apikey", apiKey)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "apisix_apikey")
 
@@ -162,17 +413,150 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	client.HTTPClient = &http.Client{Transport: admin.NewRetryTransport(retryConfig, endpointsTransport)}
+
 	// Make the APISIX client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	adminClient := admin.NewClient(client)
+
+	if validatePlugins {
+		// The plugins are listed once, while their schemas are fetched during the plan, only once the plugins are configured
+		adminClient.EnablePluginValidation()
+
+		// The failure is reported by the resources configuring the plugins
+		err = adminClient.WithContext(ctx).LoadPluginSchemas()
+		if err != nil {
+			tflog.Warn(ctx, "Could not list the plugins enabled in APISIX", map[string]any{"error": err.Error()})
+		}
+	}
+
+	resp.DataSourceData = adminClient
+	resp.ResourceData = adminClient
 
 	tflog.Info(ctx, "Configured APISIX client", map[string]any{"success": true})
 }
 
+// configureStandalone configures the client writing the objects to the declarative configuration file.
+func (p *apisixProvider) configureStandalone(ctx context.Context, config *apisixProviderModel, resp *provider.ConfigureResponse) {
+	standaloneFile := os.Getenv("APISIX_STANDALONE_FILE")
+	if !config.StandaloneFile.IsNull() && !config.StandaloneFile.IsUnknown() {
+		standaloneFile = config.StandaloneFile.ValueString()
+	}
+
+	if standaloneFile == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("standalone_file"),
+			"Missing APISIX Standalone File",
+			"The provider cannot write the APISIX configuration as there is a missing or empty value for the standalone file. "+
+				"Set the standalone_file value in the configuration or use the APISIX_STANDALONE_FILE environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "apisix_standalone_file", standaloneFile)
+	tflog.Debug(ctx, "Creating APISIX standalone client")
+
+	endpoint := admin.StandaloneEndpoint
+	apiKey := ""
+	client, err := api_client.NewClient(&endpoint, &apiKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create APISIX API Client",
+			"An unexpected error occurred when creating the APISIX API client. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"APISIX Client Error: "+err.Error(),
+		)
+		return
+	}
+	client.HTTPClient = &http.Client{Transport: admin.NewStandaloneTransport(standaloneFile)}
+
+	adminClient := admin.NewClient(client)
+	resp.DataSourceData = adminClient
+	resp.ResourceData = adminClient
+
+	tflog.Info(ctx, "Configured APISIX standalone client", map[string]any{"success": true})
+}
+
+// retryConfigFromProviderModel returns the timeout and retry settings with the defaults applied.
+// The settings default to the environment variables, overridden by the configuration values.
+func retryConfigFromProviderModel(config *apisixProviderModel) (retryConfig admin.RetryConfig, diags diag.Diagnostics) {
+	retryConfig = admin.RetryConfig{
+		RequestTimeout: defaultRequestTimeout,
+		MaxRetries:     defaultMaxRetries,
+		RetryBackoff:   defaultRetryBackoff,
+	}
+
+	requestTimeout := os.Getenv("APISIX_REQUEST_TIMEOUT")
+	if !config.RequestTimeout.IsNull() && !config.RequestTimeout.IsUnknown() {
+		requestTimeout = config.RequestTimeout.ValueString()
+	}
+
+	if requestTimeout != "" {
+		parsed, err := time.ParseDuration(requestTimeout)
+		if err != nil || parsed < 0 {
+			diags.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid APISIX API Request Timeout",
+				"The request_timeout must be a non-negative duration, such as 30s or 1m.",
+			)
+		}
+		retryConfig.RequestTimeout = parsed
+	}
+
+	if value := os.Getenv("APISIX_MAX_RETRIES"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid APISIX_MAX_RETRIES Environment Variable",
+				"The APISIX_MAX_RETRIES environment variable must be a non-negative integer.",
+			)
+		}
+		retryConfig.MaxRetries = parsed
+	}
+
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		retryConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryBackoff := os.Getenv("APISIX_RETRY_BACKOFF")
+	if !config.RetryBackoff.IsNull() && !config.RetryBackoff.IsUnknown() {
+		retryBackoff = config.RetryBackoff.ValueString()
+	}
+
+	if retryBackoff != "" {
+		parsed, err := time.ParseDuration(retryBackoff)
+		if err != nil || parsed < 0 {
+			diags.AddAttributeError(
+				path.Root("retry_backoff"),
+				"Invalid APISIX API Retry Backoff",
+				"The retry_backoff must be a non-negative duration, such as 500ms or 1s.",
+			)
+		}
+		retryConfig.RetryBackoff = parsed
+	}
+
+	return retryConfig, diags
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *apisixProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewSSLCertificateDataSource,
+		NewUpstreamDataSource,
+		NewServiceDataSource,
+		NewConsumerDataSource,
+		NewRouteDataSource,
+		NewGlobalRuleDataSource,
+		NewStreamRouteDataSource,
+		NewConsumerGroupDataSource,
+		NewPluginConfigDataSource,
+		NewRoutesDataSource,
+		NewServicesDataSource,
+		NewUpstreamsDataSource,
+		NewPluginsDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -184,6 +568,9 @@ func (p *apisixProvider) Resources(_ context.Context) []func() resource.Resource
 		NewConsumerResource,
 		NewRouteResource,
 		NewGlobalRuleResource,
+		NewPluginMetadataResource,
+		NewSecretResource,
+		NewProtoResource,
 		NewStreamRouteResource,
 		NewConsumerGroupResource,
 		NewPluginConfigResource,
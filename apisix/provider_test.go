@@ -1,19 +1,45 @@
 package apisix
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-apisix/apisix/admin"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 const (
+	// testAccEndpoint and testAccAPIKey are the APISIX Admin API used by the acceptance tests.
+	testAccEndpoint = "http://127.0.0.1:9180"
+	testAccAPIKey   = "edd1c9f034335f136f87ad84b625c8f1"
+
 	// providerConfig is a shared configuration to combine with the actual
 	// test configuration so the APISIX client is properly configured.
 	// It is also possible to use the APISIX_ environment variables instead,
 	// such as updating the Makefile and running the testing through that tool.
 	providerConfig = `
 provider "apisix" {
-	endpoint = "http://127.0.0.1:9180"
-	api_key  = "edd1c9f034335f136f87ad84b625c8f1"
+	endpoint = "` + testAccEndpoint + `"
+	api_key  = "` + testAccAPIKey + `"
 }
 `
 )
@@ -27,3 +53,376 @@ var (
 		"apisix": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// testAccAPIClient returns the client of the APISIX Admin API configured in providerConfig,
+// so the tests can change the objects outside of Terraform.
+func testAccAPIClient(t *testing.T) *admin.Client {
+	endpoint := testAccEndpoint
+	apiKey := testAccAPIKey
+
+	client, err := api_client.NewClient(&endpoint, &apiKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return admin.NewClient(client)
+}
+
+func TestProviderTLSConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid CA certificate
+			{
+				Config: `
+provider "apisix" {
+	endpoint       = "http://127.0.0.1:9180"
+	api_key        = "edd1c9f034335f136f87ad84b625c8f1"
+	ca_certificate = "not a certificate"
+}
+
+data "apisix_upstreams" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Invalid APISIX API TLS Configuration`),
+			},
+			// Client certificate without the key
+			{
+				Config: `
+provider "apisix" {
+	endpoint           = "http://127.0.0.1:9180"
+	api_key            = "edd1c9f034335f136f87ad84b625c8f1"
+	client_certificate = "not a certificate"
+}
+
+data "apisix_upstreams" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`both the client certificate and the client key must be provided`),
+			},
+		},
+	})
+}
+
+func TestProviderTLSHandshake(t *testing.T) {
+	// The CA issuing both the certificate of the Admin API and the client certificate
+	caKey := testRSAKey(t)
+	caTemplate := testCertificateTemplate("Test CA")
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign
+	// Not restricted to the server authentication, since it issues the client certificate too
+	caTemplate.ExtKeyUsage = nil
+	caCertificate, _, ca := testSignCertificate(t, caTemplate, caKey, caTemplate, caKey)
+
+	serverTemplate := testCertificateTemplate("localhost")
+	serverTemplate.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	serverCertificate, serverKey, _ := testSignCertificate(t, serverTemplate, testRSAKey(t), ca, caKey)
+
+	clientTemplate := testCertificateTemplate("terraform")
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clientCertificate, clientKey, _ := testSignCertificate(t, clientTemplate, testRSAKey(t), ca, caKey)
+
+	serverKeyPair, err := tls.X509KeyPair([]byte(serverCertificate), []byte(serverKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	// The Admin API behind TLS requiring the client certificate, which records the presented certificates
+	endpoint, err := url.Parse(testAccEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httputil.NewSingleHostReverseProxy(endpoint)
+
+	var mu sync.Mutex
+	var presented []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		for _, certificate := range r.TLS.PeerCertificates {
+			presented = append(presented, certificate.Subject.CommonName)
+		}
+		mu.Unlock()
+
+		proxy.ServeHTTP(w, r)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverKeyPair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	testCheckClientCertificatePresented := func(*terraform.State) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, commonName := range presented {
+			if commonName != "terraform" {
+				return fmt.Errorf("unexpected client certificate %s", commonName)
+			}
+		}
+		if len(presented) == 0 {
+			return fmt.Errorf("the client certificate was not presented")
+		}
+
+		return nil
+	}
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "apisix" {
+	endpoint    = %q
+	api_key     = %q
+	max_retries = 0
+%s
+}
+
+data "apisix_upstreams" "test" {}
+`, server.URL, testAccAPIKey, settings)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The certificate of the Admin API isn't trusted without the CA
+			{
+				Config: config(fmt.Sprintf(`
+	client_certificate = %q
+	client_key         = %q
+`, clientCertificate, clientKey)),
+				ExpectError: regexp.MustCompile(`certificate\s+signed\s+by\s+unknown\s+authority`),
+			},
+			// The Admin API rejects the connection without the client certificate
+			{
+				Config: config(fmt.Sprintf(`
+	ca_certificate = %q
+`, caCertificate)),
+				ExpectError: regexp.MustCompile(`tls:\s+(certificate\s+required|bad\s+certificate)`),
+			},
+			// Read testing with the CA and the client certificate
+			{
+				Config: config(fmt.Sprintf(`
+	ca_certificate     = %q
+	client_certificate = %q
+	client_key         = %q
+`, caCertificate, clientCertificate, clientKey)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.apisix_upstreams.test", "upstreams.#"),
+					testCheckClientCertificatePresented,
+				),
+			},
+			// Read testing skipping the verification of the Admin API certificate
+			{
+				Config: config(fmt.Sprintf(`
+	insecure_skip_verify = true
+	client_certificate   = %q
+	client_key           = %q
+`, clientCertificate, clientKey)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.apisix_upstreams.test", "upstreams.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderRetryConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid request timeout
+			{
+				Config: `
+provider "apisix" {
+	endpoint        = "http://127.0.0.1:9180"
+	api_key         = "edd1c9f034335f136f87ad84b625c8f1"
+	request_timeout = "30"
+}
+
+data "apisix_upstreams" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Invalid APISIX API Request Timeout`),
+			},
+			// Read testing with the retry settings
+			{
+				Config: `
+provider "apisix" {
+	endpoint        = "http://127.0.0.1:9180"
+	api_key         = "edd1c9f034335f136f87ad84b625c8f1"
+	request_timeout = "10s"
+	max_retries     = 5
+	retry_backoff   = "500ms"
+}
+
+data "apisix_upstreams" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.apisix_upstreams.test", "upstreams.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestRetryConfigFromEnvironment(t *testing.T) {
+	t.Setenv("APISIX_REQUEST_TIMEOUT", "10s")
+	t.Setenv("APISIX_MAX_RETRIES", "5")
+	t.Setenv("APISIX_RETRY_BACKOFF", "500ms")
+
+	config := apisixProviderModel{
+		RequestTimeout: types.StringNull(),
+		MaxRetries:     types.Int64Null(),
+		RetryBackoff:   types.StringValue("2s"),
+	}
+
+	retryConfig, diags := retryConfigFromProviderModel(&config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := admin.RetryConfig{
+		RequestTimeout: 10 * time.Second,
+		MaxRetries:     5,
+		// The configuration overrides the environment variable
+		RetryBackoff: 2 * time.Second,
+	}
+	if retryConfig != expected {
+		t.Errorf("expected %+v, got %+v", expected, retryConfig)
+	}
+
+	t.Setenv("APISIX_MAX_RETRIES", "-1")
+
+	_, diags = retryConfigFromProviderModel(&config)
+	if !diags.HasError() || diags[0].Summary() != "Invalid APISIX_MAX_RETRIES Environment Variable" {
+		t.Errorf("expected the invalid environment variable error, got %v", diags)
+	}
+}
+
+func TestProviderEndpointsFailover(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Conflicting endpoint and endpoints
+			{
+				Config: `
+provider "apisix" {
+	endpoint  = "http://127.0.0.1:9180"
+	endpoints = ["http://127.0.0.1:9180"]
+	api_key   = "edd1c9f034335f136f87ad84b625c8f1"
+}
+
+data "apisix_upstreams" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Create and Read testing with the unavailable endpoint
+			{
+				Config: `
+provider "apisix" {
+	endpoints = ["http://127.0.0.1:1", "http://127.0.0.1:9180"]
+	api_key   = "edd1c9f034335f136f87ad84b625c8f1"
+}
+
+resource "apisix_upstream" "test" {
+	type = "roundrobin"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1980
+		}
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_upstream.test", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestProviderStandaloneMode(t *testing.T) {
+	standaloneFile := filepath.Join(t.TempDir(), "apisix.yaml")
+	config := fmt.Sprintf(`
+provider "apisix" {
+	mode            = "standalone"
+	standalone_file = %q
+}
+
+resource "apisix_upstream" "test" {
+	type = "roundrobin"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1980
+		}
+	]
+}
+
+resource "apisix_route" "test" {
+	uri         = "/standalone"
+	upstream_id = apisix_upstream.test.id
+}
+`, standaloneFile)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing standalone file
+			{
+				Config: `
+provider "apisix" {
+	mode = "standalone"
+}
+
+data "apisix_upstreams" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Missing APISIX Standalone File`),
+			},
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_route.test", "id"),
+					func(s *terraform.State) error {
+						data, err := os.ReadFile(standaloneFile)
+						if err != nil {
+							return err
+						}
+
+						content := string(data)
+						for _, expected := range []string{"upstreams:", "routes:", "uri: /standalone", "#END"} {
+							if !strings.Contains(content, expected) {
+								return fmt.Errorf("expected %q in the standalone file:\n%s", expected, content)
+							}
+						}
+
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			data, err := os.ReadFile(standaloneFile)
+			if err != nil {
+				return err
+			}
+
+			if strings.TrimSpace(string(data)) != "{}\n#END" {
+				return fmt.Errorf("expected the empty standalone file, got:\n%s", data)
+			}
+
+			return nil
+		},
+	})
+}
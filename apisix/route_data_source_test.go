@@ -0,0 +1,44 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRouteDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	name    = "Example"
+	desc    = "Example of the route data source"
+	uri     = "/api/v1"
+	methods = ["GET"]
+	labels = {
+		"version" = "v1"
+	}
+}
+
+data "apisix_route" "test" {
+	id = apisix_route.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "id", "apisix_route.test", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "name", "apisix_route.test", "name"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "desc", "apisix_route.test", "desc"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "uri", "apisix_route.test", "uri"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "methods.#", "apisix_route.test", "methods.#"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "labels.version", "apisix_route.test", "labels.version"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "priority", "apisix_route.test", "priority"),
+					resource.TestCheckResourceAttrPair("data.apisix_route.test", "status", "apisix_route.test", "status"),
+				),
+			},
+		},
+	})
+}
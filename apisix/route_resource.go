@@ -4,14 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -21,6 +19,7 @@ var (
 	_ resource.ResourceWithConfigure        = &routeResource{}
 	_ resource.ResourceWithImportState      = &routeResource{}
 	_ resource.ResourceWithConfigValidators = &routeResource{}
+	_ resource.ResourceWithModifyPlan       = &routeResource{}
 )
 
 // NewRouteResource is a helper function to simplify the provider implementation.
@@ -30,7 +29,7 @@ func NewRouteResource() resource.Resource {
 
 // routeResource is the resource implementation.
 type routeResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -63,21 +62,36 @@ func (r *routeResource) ConfigValidators(ctx context.Context) []resource.ConfigV
 			path.MatchRoot("plugin_config_id"),
 			path.MatchRoot("script"),
 		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("upstream"),
+			path.MatchRoot("upstream_id"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("typed_plugins"),
+			path.MatchRoot("plugin_config_id"),
+			path.MatchRoot("script"),
+		),
+		model.TypedPluginsConfigValidator(),
 	}
 }
 
+// ModifyPlan validates the plugins against the plugin schemas of APISIX.
+func (r *routeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validatePlugins(ctx, r.client, req.Plan, admin.SubsystemHTTP, admin.SchemaTypeRoute)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *routeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -96,11 +110,43 @@ func (r *routeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	newRouteRequest := model.RouteFromTerraformToApi(ctx, &plan)
+	newRouteRequest, diags := model.RouteFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Create a new route
-	newRouteResponse, err := r.client.CreateRoute(newRouteRequest)
+	// Fail instead of overwriting the object with the configured identifier created outside of Terraform
+	if !plan.ID.IsUnknown() {
+		resp.Diagnostics.Append(validateCreate(client, "Route", "apisix_route", plan.ID.ValueString(), func(client *admin.Client) error {
+			_, err := client.GetRoute(plan.ID.ValueString())
+			return err
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create a new route, using the configured identifier or the one generated by APISIX
+	var newRouteResponse *admin.Route
+	var err error
+	if plan.ID.IsUnknown() {
+		newRouteResponse, err = client.CreateRoute(newRouteRequest)
+	} else {
+		newRouteResponse, err = client.UpdateRoute(plan.ID.ValueString(), newRouteRequest)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Route",
@@ -110,11 +156,25 @@ func (r *routeResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	newState := model.RouteFromApiToTerraform(ctx, newRouteResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.RouteFromApiToTerraform(ctx, newRouteResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -134,9 +194,27 @@ func (r *routeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed route from the APISIX
-	routeStateResponse, err := r.client.GetRoute(state.ID.ValueString())
+	routeStateResponse, err := client.GetRoute(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The route is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Route",
 			"Could not read APISIX Route by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -145,11 +223,25 @@ func (r *routeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Overwrite with refreshed state
-	newState := model.RouteFromApiToTerraform(ctx, routeStateResponse)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+	newState, diags := model.RouteFromApiToTerraform(ctx, routeStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, state.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -169,11 +261,26 @@ func (r *routeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	updateRouteRequest := model.RouteFromTerraformToApi(ctx, &plan)
+	updateRouteRequest, diags := model.RouteFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update existing route
-	_, err := r.client.UpdateRoute(plan.ID.ValueString(), updateRouteRequest)
+	_, err := client.UpdateRoute(plan.ID.ValueString(), updateRouteRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Route",
@@ -183,7 +290,7 @@ func (r *routeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Fetch updated route
-	updatedRoute, err := r.client.GetRoute(plan.ID.ValueString())
+	updatedRoute, err := client.GetRoute(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Route",
@@ -192,11 +299,25 @@ func (r *routeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	newState := model.RouteFromApiToTerraform(ctx, updatedRoute)
-	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+	newState, diags := model.RouteFromApiToTerraform(ctx, updatedRoute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemHTTP, admin.SchemaTypeRoute)
+
+	// Move the plugins configured with the typed attributes out of the plugins JSON
+	diags = model.TypedPluginsSplit(ctx, &newState.Plugins, &newState.TypedPlugins, plan.TypedPlugins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -216,8 +337,18 @@ func (r *routeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete the route
-	err := r.client.DeleteRoute(state.ID.ValueString())
+	err := client.DeleteRoute(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Route",
@@ -230,6 +361,15 @@ func (r *routeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 // Import resource into state
 func (r *routeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the route importing")
+	// Validate the import ID and check the route exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Route", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetRoute(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -1,6 +1,7 @@
 package apisix
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -105,3 +106,401 @@ resource "apisix_route" "test" {
 		},
 	})
 }
+
+func TestRouteResourceInvalidJSON(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid plugins
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/invalid"
+	plugins = "{\"ip-restriction\": "
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`value must be a valid JSON object`),
+			},
+			// Invalid vars
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri  = "/invalid"
+	vars = jsonencode({ http_user = "ios" })
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`value must be a valid JSON array`),
+			},
+		},
+	})
+}
+
+func TestRouteResourcePluginsSchema(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Plugin not enabled in APISIX
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/schema"
+	plugins = jsonencode({ unknown-plugin = {} })
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unknown Plugin`),
+			},
+			// Plugin configuration not matching the schema
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/schema"
+	plugins = jsonencode({ limit-count = { count = "ten" } })
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Plugin Configuration.*/count`),
+			},
+		},
+	})
+}
+
+func TestRouteResourcePluginsDrift(t *testing.T) {
+	var routeID string
+
+	config := providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/drift"
+	plugins = <<EOT
+{
+	"limit-count": {"time_window": 60, "count": 10},
+	"prometheus":  {}
+}
+EOT
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Key ordering, whitespace and the plugin defaults set by APISIX don't produce a diff
+			{
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("apisix_route.test", "id", func(value string) error {
+					routeID = value
+					return nil
+				}),
+			},
+			// Plugins changed outside of Terraform are detected
+			{
+				PreConfig: func() {
+					client := testAccAPIClient(t)
+
+					route, err := client.GetRoute(routeID)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					(*route.Plugins)["limit-count"].(map[string]interface{})["count"] = 20
+					route.ID = nil
+
+					_, err = client.UpdateRoute(routeID, *route)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestRouteResourceInlineUpstream(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Conflicting upstream and upstream_id
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri         = "/inline"
+	upstream_id = "1"
+	upstream = {
+		nodes = [
+			{
+				host = "127.0.0.1"
+				port = 1980
+			},
+		]
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Inline upstream without nodes and service_name
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/inline"
+	upstream = {
+		type = "roundrobin"
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Exactly one of upstream.nodes and\s+upstream.service_name`),
+			},
+			// Inline upstream with both nodes and service_name
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/inline"
+	upstream = {
+		service_name   = "httpbin"
+		discovery_type = "dns"
+		nodes = [
+			{
+				host = "127.0.0.1"
+				port = 1980
+			},
+		]
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Exactly one of upstream.nodes and\s+upstream.service_name`),
+			},
+			// Inline upstream service_name without discovery_type
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/inline"
+	upstream = {
+		service_name = "httpbin"
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)upstream.service_name and\s+upstream.discovery_type must be configured\s+together`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/inline"
+	upstream = {
+		nodes = [
+			{
+				host = "127.0.0.1"
+				port = 1980
+			},
+		]
+		timeout = {
+			connect = 3
+			send    = 3
+			read    = 3
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_route.test", "id"),
+					resource.TestCheckResourceAttr("apisix_route.test", "upstream.type", "roundrobin"),
+					resource.TestCheckResourceAttr("apisix_route.test", "upstream.nodes.0.weight", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/inline"
+	upstream = {
+		type = "chash"
+		key  = "remote_addr"
+		nodes = [
+			{
+				host   = "127.0.0.1"
+				port   = 1980
+				weight = 2
+			},
+			{
+				host = "127.0.0.1"
+				port = 1970
+			},
+		]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "upstream.type", "chash"),
+					resource.TestCheckResourceAttr("apisix_route.test", "upstream.nodes.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestRouteResourceDisappears(t *testing.T) {
+	var routeID string
+
+	config := providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/disappears"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("apisix_route.test", "id", func(value string) error {
+					routeID = value
+					return nil
+				}),
+			},
+			// The route deleted outside of Terraform is planned to be created again
+			{
+				PreConfig: func() {
+					err := testAccAPIClient(t).DeleteRoute(routeID)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestRouteResourceInvalidImportID(t *testing.T) {
+	config := providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/import"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Identifier with the invalid characters
+			{
+				Config:        config,
+				ResourceName:  "apisix_route.test",
+				ImportState:   true,
+				ImportStateId: "invalid/id",
+				ExpectError:   regexp.MustCompile(`Invalid Import Identifier`),
+			},
+			// Identifier of the missing route
+			{
+				Config:        config,
+				ResourceName:  "apisix_route.test",
+				ImportState:   true,
+				ImportStateId: "missing",
+				ExpectError:   regexp.MustCompile(`Cannot Import Non-Existent APISIX Route`),
+			},
+		},
+	})
+}
+
+func TestRouteResourceTypedPlugins(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The same plugin can't be configured in both plugins and typed_plugins
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/typed"
+	plugins = jsonencode({ limit-count = { count = 10, time_window = 60 } })
+	typed_plugins = {
+		limit_count = {
+			count       = 10
+			time_window = 60
+		}
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting Plugin Configuration`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/typed"
+	plugins = jsonencode({ prometheus = {} })
+	typed_plugins = {
+		limit_count = {
+			count       = 10
+			time_window = 60
+		}
+		ip_restriction = {
+			whitelist = ["10.0.0.0/8"]
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.limit_count.count", "10"),
+					// Defaults set by APISIX
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.limit_count.rejected_code", "503"),
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.limit_count.key", "remote_addr"),
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.ip_restriction.whitelist.0", "10.0.0.0/8"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "typed_plugins.cors"),
+				),
+			},
+			// ImportState testing, the imported route has all the plugins in the plugins JSON
+			{
+				ResourceName:            "apisix_route.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"plugins", "typed_plugins"},
+			},
+			// Update and Read testing, the typed plugins only
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/typed"
+	typed_plugins = {
+		limit_count = {
+			count         = 20
+			time_window   = 60
+			rejected_code = 429
+		}
+		proxy_rewrite = {
+			regex_uri = ["^/typed/(.*)", "/$1"]
+			headers = {
+				set = {
+					X-Api-Version = "v1"
+				}
+			}
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.limit_count.count", "20"),
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.limit_count.rejected_code", "429"),
+					resource.TestCheckResourceAttr("apisix_route.test", "typed_plugins.proxy_rewrite.headers.set.X-Api-Version", "v1"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "plugins"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
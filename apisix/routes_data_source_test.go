@@ -0,0 +1,64 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRoutesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "payments" {
+	name  = "payments-api"
+	uri   = "/payments"
+	hosts = ["pay.example.com"]
+	labels = {
+		team = "payments-ds-test"
+	}
+}
+
+resource "apisix_route" "orders" {
+	name = "orders-api"
+	uri  = "/orders"
+	labels = {
+		team = "orders-ds-test"
+	}
+}
+
+data "apisix_routes" "by_labels" {
+	labels = {
+		team = "payments-ds-test"
+	}
+	depends_on = [apisix_route.payments, apisix_route.orders]
+}
+
+data "apisix_routes" "by_uri" {
+	uri        = "/orders"
+	depends_on = [apisix_route.payments, apisix_route.orders]
+}
+
+data "apisix_routes" "by_name_prefix_and_host" {
+	name_prefix = "payments-"
+	host        = "pay.example.com"
+	depends_on  = [apisix_route.payments, apisix_route.orders]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the label selector
+					resource.TestCheckResourceAttr("data.apisix_routes.by_labels", "routes.#", "1"),
+					resource.TestCheckResourceAttrPair("data.apisix_routes.by_labels", "routes.0.id", "apisix_route.payments", "id"),
+					// Verify the URI filter
+					resource.TestCheckResourceAttrPair("data.apisix_routes.by_uri", "routes.0.id", "apisix_route.orders", "id"),
+					// Verify the name prefix and host filters
+					resource.TestCheckResourceAttr("data.apisix_routes.by_name_prefix_and_host", "routes.#", "1"),
+					resource.TestCheckResourceAttr("data.apisix_routes.by_name_prefix_and_host", "routes.0.name", "payments-api"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,314 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &secretResource{}
+	_ resource.ResourceWithConfigure        = &secretResource{}
+	_ resource.ResourceWithImportState      = &secretResource{}
+	_ resource.ResourceWithConfigValidators = &secretResource{}
+)
+
+// NewSecretResource is a helper function to simplify the provider implementation.
+func NewSecretResource() resource.Resource {
+	return &secretResource{}
+}
+
+// secretResource is the resource implementation.
+type secretResource struct {
+	client *admin.Client
+}
+
+// Metadata returns the resource type name.
+func (r *secretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+// Schema defines the schema for the resource.
+func (r *secretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.SecretSchema
+}
+
+// Validate Config
+func (r *secretResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("vault"),
+			path.MatchRoot("aws"),
+		),
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *secretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create a new resource.
+func (r *secretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Start of the secret resource creation")
+	// Retrieve values from plan
+	var plan model.SecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Generate API request body from plan
+	manager := model.SecretManager(&plan)
+	newSecretRequest := model.SecretFromTerraformToApi(ctx, &plan)
+
+	// Create new secret
+	newSecretResponse, err := client.CreateSecret(manager, plan.SecretID.ValueString(), newSecretRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Secret",
+			"Could not create Secret, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	newState := model.SecretFromApiToTerraform(ctx, manager, plan.SecretID.ValueString(), newSecretResponse)
+	model.SecretKeepCredentials(&newState, &plan)
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *secretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the secret resource read")
+	// Get current state
+	var state model.SecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	manager, secretID, err := parseSecretID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Secret",
+			err.Error(),
+		)
+		return
+	}
+
+	// Get refreshed secret from the APISIX
+	secretStateResponse, err := client.GetSecret(manager, secretID)
+	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The secret is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Secret",
+			"Could not read APISIX Secret by ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite with refreshed state
+	newState := model.SecretFromApiToTerraform(ctx, manager, secretID, secretStateResponse)
+	model.SecretKeepCredentials(&newState, &state)
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update the resource.
+func (r *secretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Start of the secret resource update")
+	// Retrieve values from plan
+	var plan model.SecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Generate API request body from plan
+	manager := model.SecretManager(&plan)
+	updateSecretRequest := model.SecretFromTerraformToApi(ctx, &plan)
+
+	// Update existing secret
+	_, err := client.UpdateSecret(manager, plan.SecretID.ValueString(), updateSecretRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating APISIX Secret",
+			"Could not update Secret, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Fetch updated secret
+	updatedSecret, err := client.GetSecret(manager, plan.SecretID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Secret",
+			"Could not read APISIX Secret by ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	newState := model.SecretFromApiToTerraform(ctx, manager, plan.SecretID.ValueString(), updatedSecret)
+	model.SecretKeepCredentials(&newState, &plan)
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource.
+func (r *secretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Start of the secret resource delete")
+	// Get current state
+	var state model.SecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
+	// Delete the secret
+	err := client.DeleteSecret(model.SecretManager(&state), state.SecretID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting APISIX Secret",
+			"Could not delete Secret, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Import resource into state
+func (r *secretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Start of the secret importing")
+	// Validate the import ID in the form of manager/id and check the secret exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Secret", req.ID, func(id string) error {
+		_, _, err := parseSecretID(id)
+		return err
+	}, func(client *admin.Client) error {
+		manager, secretID, _ := parseSecretID(req.ID)
+		_, err := client.GetSecret(manager, secretID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, secretID, _ := parseSecretID(req.ID)
+
+	// Retrieve import ID and save to id attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secret_id"), secretID)...)
+}
+
+// parseSecretID splits the secret identifier in the form of manager/id.
+func parseSecretID(id string) (manager string, secretID string, err error) {
+	manager, secretID, found := strings.Cut(id, "/")
+	if !found || secretID == "" || (manager != model.SecretManagerVault && manager != model.SecretManagerAWS) {
+		return "", "", fmt.Errorf("expected the secret identifier in the form of manager/id, where manager is vault or aws, got: %q", id)
+	}
+
+	err = model.ValidateObjectID(secretID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return manager, secretID, nil
+}
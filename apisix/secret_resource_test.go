@@ -0,0 +1,116 @@
+package apisix
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSecretResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	secret_id = "test"
+	vault = {
+		uri    = "http://127.0.0.1:8200"
+		prefix = "kv/apisix"
+		token  = "root"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// NOTE: State value checking is only necessary for Computed attributes,
+					//       as the testing framework will automatically return test failures
+					//       for configured attributes that mismatch the saved state.
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttr("apisix_secret.test", "id", "vault/test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_secret.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	secret_id = "test"
+	vault = {
+		uri       = "http://127.0.0.1:8200"
+		prefix    = "kv/apisix"
+		token     = "updated"
+		namespace = "team"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_secret.test", "id", "vault/test"),
+				),
+			},
+			// Replace with another secret manager
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	secret_id = "test"
+	aws = {
+		access_key_id     = "access"
+		secret_access_key = "secret"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_secret.test", "id", "aws/test"),
+					resource.TestCheckResourceAttr("apisix_secret.test", "aws.region", "us-east-1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSecretResourceInvalidImportID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	secret_id = "test"
+	vault = {
+		uri    = "http://127.0.0.1:8200"
+		prefix = "kv/apisix"
+		token  = "root"
+	}
+}
+`,
+				ResourceName:  "apisix_secret.test",
+				ImportState:   true,
+				ImportStateId: "test",
+				ExpectError:   regexp.MustCompile(`expected the secret identifier in the form of manager/id`),
+			},
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	secret_id = "test"
+	vault = {
+		uri    = "http://127.0.0.1:8200"
+		prefix = "kv/apisix"
+		token  = "root"
+	}
+}
+`,
+				ResourceName:  "apisix_secret.test",
+				ImportState:   true,
+				ImportStateId: "vault/missing",
+				ExpectError:   regexp.MustCompile(`Cannot Import Non-Existent APISIX Secret`),
+			},
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestServiceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_service" "test" {
+	name  = "Example"
+	desc  = "Example of the service data source"
+	hosts = ["foo.com"]
+}
+
+data "apisix_service" "test" {
+	id = apisix_service.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_service.test", "id", "apisix_service.test", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_service.test", "name", "apisix_service.test", "name"),
+					resource.TestCheckResourceAttrPair("data.apisix_service.test", "desc", "apisix_service.test", "desc"),
+					resource.TestCheckResourceAttrPair("data.apisix_service.test", "hosts.#", "apisix_service.test", "hosts.#"),
+					resource.TestCheckResourceAttrPair("data.apisix_service.test", "enable_websocket", "apisix_service.test", "enable_websocket"),
+				),
+			},
+		},
+	})
+}
@@ -59,3 +59,51 @@ resource "apisix_service" "test" {
 		},
 	})
 }
+
+func TestServiceResourceInlineUpstream(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_service" "test" {
+	name = "inline-upstream"
+	upstream = {
+		scheme = "https"
+		nodes = [
+			{
+				host = "127.0.0.1"
+				port = 1980
+			},
+		]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_service.test", "id"),
+					resource.TestCheckResourceAttr("apisix_service.test", "upstream.scheme", "https"),
+					resource.TestCheckResourceAttr("apisix_service.test", "upstream.pass_host", "pass"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_service" "test" {
+	name = "inline-upstream"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("apisix_service.test", "upstream"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestServicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_service" "payments" {
+	name  = "payments-service"
+	hosts = ["pay.example.com"]
+	labels = {
+		team = "payments-ds-test"
+	}
+}
+
+resource "apisix_service" "orders" {
+	name = "orders-service"
+	labels = {
+		team = "orders-ds-test"
+	}
+}
+
+data "apisix_services" "by_labels" {
+	labels = {
+		team = "payments-ds-test"
+	}
+	depends_on = [apisix_service.payments, apisix_service.orders]
+}
+
+data "apisix_services" "by_host" {
+	host       = "pay.example.com"
+	depends_on = [apisix_service.payments, apisix_service.orders]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.apisix_services.by_labels", "services.#", "1"),
+					resource.TestCheckResourceAttrPair("data.apisix_services.by_labels", "services.0.id", "apisix_service.payments", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_services.by_host", "services.0.id", "apisix_service.payments", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,99 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sslCertificateDataSource{}
+	_ datasource.DataSourceWithConfigure = &sslCertificateDataSource{}
+)
+
+// NewSSLCertificateDataSource is a helper function to simplify the provider implementation.
+func NewSSLCertificateDataSource() datasource.DataSource {
+	return &sslCertificateDataSource{}
+}
+
+// sslCertificateDataSource is the data source implementation.
+type sslCertificateDataSource struct {
+	client *admin.Client
+}
+
+// Metadata returns the data source type name.
+func (d *sslCertificateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_certificate"
+}
+
+// Schema defines the schema for the data source.
+func (d *sslCertificateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dataSourceSchema, err := model.SSLCertificateDataSourceSchema()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Data Source Schema",
+			"Could not build the SSL certificate data source schema, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Schema = dataSourceSchema
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *sslCertificateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *sslCertificateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the SSL certificate data source read")
+	// Get the SSL certificate lookup configuration
+	var id types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("id"), &id)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get SSL certificate from the APISIX
+	certificateResponse, err := d.client.GetSslCertificate(id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX SSL Certificate",
+			"Could not read APISIX SSL Certificate ID "+id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state := model.SSLCertificateFromAPIToTerraform(ctx, certificateResponse)
+
+	// Set state
+	diags = model.SetDataSourceState(ctx, &resp.State, model.SSLCertificateSchema, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -3,11 +3,12 @@ package apisix
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -16,10 +17,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &sslCertificateResource{}
-	_ resource.ResourceWithConfigure   = &sslCertificateResource{}
-	_ resource.ResourceWithImportState = &sslCertificateResource{}
-	_ resource.ResourceWithModifyPlan  = &sslCertificateResource{}
+	_ resource.Resource                   = &sslCertificateResource{}
+	_ resource.ResourceWithConfigure      = &sslCertificateResource{}
+	_ resource.ResourceWithImportState    = &sslCertificateResource{}
+	_ resource.ResourceWithModifyPlan     = &sslCertificateResource{}
+	_ resource.ResourceWithValidateConfig = &sslCertificateResource{}
 )
 
 // NewSSLCertificateResource is a helper function to simplify the provider implementation.
@@ -29,7 +31,7 @@ func NewSSLCertificateResource() resource.Resource {
 
 // sslCertificateResource is the resource implementation.
 type sslCertificateResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -42,6 +44,55 @@ func (r *sslCertificateResource) Schema(_ context.Context, _ resource.SchemaRequ
 	resp.Schema = model.SSLCertificateSchema
 }
 
+// ValidateConfig checks the certificate chain, the private key and the SNIs, so the invalid certificates
+// are reported before APISIX rejects them or, even worse, serves them.
+func (r *sslCertificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config model.SSLCertificateResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The certificate is validated once its value is known
+	if config.Certificate.IsNull() || config.Certificate.IsUnknown() {
+		return
+	}
+
+	err := model.ValidateCertificateChain(config.Certificate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate"),
+			"Invalid Certificate Chain",
+			"The certificate must be a PEM bundle starting with the leaf certificate followed by its intermediate certificates: "+err.Error(),
+		)
+		return
+	}
+
+	if !config.PrivateKey.IsNull() && !config.PrivateKey.IsUnknown() {
+		err = model.ValidateCertificateKey(config.Certificate.ValueString(), config.PrivateKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("private_key"),
+				"Invalid Private Key",
+				"The private key doesn't match the certificate: "+err.Error(),
+			)
+		}
+	}
+
+	var snis []string
+	if !config.Snis.IsNull() && !config.Snis.ElementsAs(ctx, &snis, false).HasError() {
+		err = model.ValidateCertificateSNIs(config.Certificate.ValueString(), snis)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("snis"),
+				"Invalid SNIs",
+				"The SNIs must be covered by the subject alternative names of the certificate: "+err.Error(),
+			)
+		}
+	}
+}
+
 // Implement plan modification
 func (r *sslCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// If the entire plan is null, the resource is planned for destruction.
@@ -51,16 +102,72 @@ func (r *sslCertificateResource) ModifyPlan(ctx context.Context, req resource.Mo
 		return
 	}
 
-	// Retrieve snis value from plan
-	var state model.SSLCertificateResourceModel
-	diags := req.Config.Get(ctx, &state)
+	// Retrieve the configured values
+	var config model.SSLCertificateResourceModel
+	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if state.Snis.IsNull() || len(state.Snis.Elements()) == 0 {
-		snis, err := model.CertSNIS(state.Certificate.ValueString(), state.PrivateKey.ValueString())
+	// Validate the additional certificates match their private keys, once the values are known
+	var certificates, privateKeys []string
+	if !config.AdditionalCertificates.IsNull() && !config.AdditionalPrivateKeys.IsNull() &&
+		!config.AdditionalCertificates.ElementsAs(ctx, &certificates, false).HasError() &&
+		!config.AdditionalPrivateKeys.ElementsAs(ctx, &privateKeys, false).HasError() {
+		err := model.ValidateAdditionalCertificates(certificates, privateKeys)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("additional_certificates"),
+				"Invalid Additional Certificates",
+				"The additional certificates must match the additional private keys in the same order: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Show the metadata of the planned certificate in the plan, once the certificate is known
+	if !config.Certificate.IsUnknown() {
+		var plan model.SSLCertificateResourceModel
+		diags := req.Plan.Get(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		plan.SetCertificateMetadata()
+
+		diags = resp.Plan.Set(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Guard against the expired certificates and the ones about to expire
+		resp.Diagnostics.Append(certificateExpiryDiagnostics(config.Certificate.ValueString(), config.MinDaysRemaining, path.Root("certificate"))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The additional certificates are served for the same SNIs, so they are guarded as well
+	var additionalCertificates []types.String
+	if !config.AdditionalCertificates.IsNull() && !config.AdditionalCertificates.IsUnknown() &&
+		!config.AdditionalCertificates.ElementsAs(ctx, &additionalCertificates, false).HasError() {
+		for i, certificate := range additionalCertificates {
+			if certificate.IsNull() || certificate.IsUnknown() {
+				continue
+			}
+
+			resp.Diagnostics.Append(certificateExpiryDiagnostics(certificate.ValueString(), config.MinDaysRemaining, path.Root("additional_certificates").AtListIndex(i))...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if config.Snis.IsNull() || len(config.Snis.Elements()) == 0 {
+		snis, err := model.CertSNIS(config.Certificate.ValueString(), config.PrivateKey.ValueString())
 		if err != nil {
 			tflog.Error(ctx, "Error. SNIS can't be determined")
 		}
@@ -76,18 +183,53 @@ func (r *sslCertificateResource) ModifyPlan(ctx context.Context, req resource.Mo
 
 }
 
+// certificateExpiryDiagnostics reports the expired certificate and the one expiring in less than min_days_remaining.
+func certificateExpiryDiagnostics(certificate string, minDaysRemaining types.Int64, attributePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The invalid certificates are reported by the validation of the configuration
+	chain, err := model.ParsePEMCertificates(certificate)
+	if err != nil {
+		return diags
+	}
+
+	notAfter := chain[0].NotAfter
+	remaining := time.Until(notAfter)
+
+	if remaining <= 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Expired Certificate",
+			"The certificate expired at "+notAfter.UTC().Format(time.RFC3339)+". Renew the certificate.",
+		)
+		return diags
+	}
+
+	if !minDaysRemaining.IsNull() && !minDaysRemaining.IsUnknown() &&
+		remaining < time.Duration(minDaysRemaining.ValueInt64())*24*time.Hour {
+		diags.AddAttributeError(
+			attributePath,
+			"Certificate Expires Soon",
+			fmt.Sprintf("The certificate expires at %s, in %d days, while min_days_remaining requires at least %d days. Renew the certificate.",
+				notAfter.UTC().Format(time.RFC3339), int64(remaining.Hours()/24), minDaysRemaining.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *sslCertificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -107,11 +249,38 @@ func (r *sslCertificateResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
 	newCertificateRequest := model.SSLCertificateFromTerraformToAPI(ctx, &plan)
 
-	// Create new certificate
-	newCertificateResponse, err := r.client.CreateSslCertificate(newCertificateRequest)
+	// Fail instead of overwriting the object with the configured identifier created outside of Terraform
+	if !plan.ID.IsUnknown() {
+		resp.Diagnostics.Append(validateCreate(client, "SSL Certificate", "apisix_ssl_certificate", plan.ID.ValueString(), func(client *admin.Client) error {
+			_, err := client.GetSslCertificate(plan.ID.ValueString())
+			return err
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create new certificate, using the configured identifier or the one generated by APISIX
+	var newCertificateResponse *admin.SSLCertificate
+	var err error
+	if plan.ID.IsUnknown() {
+		newCertificateResponse, err = client.CreateSslCertificate(newCertificateRequest)
+	} else {
+		newCertificateResponse, err = client.UpdateSslCertificate(plan.ID.ValueString(), newCertificateRequest)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating SSL certificate",
@@ -123,6 +292,11 @@ func (r *sslCertificateResource) Create(ctx context.Context, req resource.Create
 	// Map response body to schema and populate Computed attribute values
 	newState := model.SSLCertificateFromAPIToTerraform(ctx, newCertificateResponse)
 	newState.PrivateKey = types.StringValue(plan.PrivateKey.ValueString())
+	newState.AdditionalPrivateKeys = plan.AdditionalPrivateKeys
+	newState.MinDaysRemaining = plan.MinDaysRemaining
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -143,9 +317,27 @@ func (r *sslCertificateResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed certificate from the APISIX
-	certificateStatusResponse, err := r.client.GetSslCertificate(state.ID.ValueString())
+	certificateStatusResponse, err := client.GetSslCertificate(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The SSL certificate is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX SSL Certificate",
 			"Could not read APISIX SSL Certificate ID "+state.ID.ValueString()+": "+err.Error(),
@@ -156,6 +348,11 @@ func (r *sslCertificateResource) Read(ctx context.Context, req resource.ReadRequ
 	// Overwrite with refreshed state
 	newState := model.SSLCertificateFromAPIToTerraform(ctx, certificateStatusResponse)
 	newState.PrivateKey = types.StringValue(state.PrivateKey.ValueString())
+	newState.AdditionalPrivateKeys = state.AdditionalPrivateKeys
+	newState.MinDaysRemaining = state.MinDaysRemaining
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -177,11 +374,21 @@ func (r *sslCertificateResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
 	updateCertificateRequest := model.SSLCertificateFromTerraformToAPI(ctx, &plan)
 
 	// Update existing certificate
-	_, err := r.client.UpdateSslCertificate(plan.ID.ValueString(), updateCertificateRequest)
+	_, err := client.UpdateSslCertificate(plan.ID.ValueString(), updateCertificateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX SSL Certificate",
@@ -191,7 +398,7 @@ func (r *sslCertificateResource) Update(ctx context.Context, req resource.Update
 	}
 
 	// Fetch updated certificate
-	updatedCertificate, err := r.client.GetSslCertificate(plan.ID.ValueString())
+	updatedCertificate, err := client.GetSslCertificate(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX SSL Certificate",
@@ -202,6 +409,11 @@ func (r *sslCertificateResource) Update(ctx context.Context, req resource.Update
 
 	newState := model.SSLCertificateFromAPIToTerraform(ctx, updatedCertificate)
 	newState.PrivateKey = types.StringValue(plan.PrivateKey.ValueString())
+	newState.AdditionalPrivateKeys = plan.AdditionalPrivateKeys
+	newState.MinDaysRemaining = plan.MinDaysRemaining
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -222,8 +434,18 @@ func (r *sslCertificateResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete existing certificate
-	err := r.client.DeleteSslCertificate(state.ID.ValueString())
+	err := client.DeleteSslCertificate(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX SSL Certificate",
@@ -235,6 +457,15 @@ func (r *sslCertificateResource) Delete(ctx context.Context, req resource.Delete
 
 // Import resource into state
 func (r *sslCertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID and check the SSL certificate exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "SSL Certificate", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetSslCertificate(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
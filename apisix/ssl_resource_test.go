@@ -1,7 +1,21 @@
 package apisix
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -74,3 +88,331 @@ resource "apisix_ssl_certificate" "test" {
 		},
 	})
 }
+
+func TestSSLResourceAdditionalCertificates(t *testing.T) {
+	rsaCertificate, rsaPrivateKey := testCertificate(t, testRSAKey(t), "dual.example.com")
+	ecdsaCertificate, ecdsaPrivateKey := testCertificate(t, testECDSAKey(t), "dual.example.com")
+
+	expiredTemplate := testCertificateTemplate("dual.example.com")
+	expiredTemplate.NotBefore = time.Now().Add(-48 * time.Hour)
+	expiredTemplate.NotAfter = time.Now().Add(-24 * time.Hour)
+	expiredKey := testECDSAKey(t)
+	expiredCertificate, expiredPrivateKey, _ := testSignCertificate(t, expiredTemplate, expiredKey, expiredTemplate, expiredKey)
+
+	config := func(additionalCertificate, additionalPrivateKey string, minDaysRemaining int) string {
+		return providerConfig + fmt.Sprintf(`
+resource "apisix_ssl_certificate" "test" {
+	certificate             = %q
+	private_key             = %q
+	additional_certificates = [%q]
+	additional_private_keys = [%q]
+	min_days_remaining      = %d
+}
+`, rsaCertificate, rsaPrivateKey, additionalCertificate, additionalPrivateKey, minDaysRemaining)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The additional certificate doesn't match the private key
+			{
+				Config:      config(ecdsaCertificate, rsaPrivateKey, 30),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Additional Certificates`),
+			},
+			// The additional certificate is expired
+			{
+				Config:      config(expiredCertificate, expiredPrivateKey, 30),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Expired Certificate`),
+			},
+			// The additional certificate expires in less than the minimum days
+			{
+				Config:      config(ecdsaCertificate, ecdsaPrivateKey, 120),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Certificate Expires Soon`),
+			},
+			// Create and Read testing
+			{
+				Config: config(ecdsaCertificate, ecdsaPrivateKey, 30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "additional_certificates.#", "1"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "additional_private_keys.#", "1"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "snis.0", "dual.example.com"),
+				),
+			},
+			// ImportState testing, APISIX returns the private keys encrypted
+			{
+				ResourceName:            "apisix_ssl_certificate.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key", "additional_private_keys", "min_days_remaining"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSSLResourceClient(t *testing.T) {
+	certificate, privateKey := testCertificate(t, testRSAKey(t), "mtls.example.com")
+	ca, _ := testCertificate(t, testECDSAKey(t), "ca.example.com")
+
+	config := func(client string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "apisix_ssl_certificate" "test" {
+	certificate = %q
+	private_key = %q
+	client      = %s
+}
+`, certificate, privateKey, client)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The CA isn't a PEM certificate
+			{
+				Config:      config(`{ ca = "not a certificate" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid PEM Certificates`),
+			},
+			// Create and Read testing
+			{
+				Config: config(fmt.Sprintf(`{ ca = %q }`, ca)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "client.ca", ca),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "client.depth", "1"),
+					resource.TestCheckNoResourceAttr("apisix_ssl_certificate.test", "client.skip_mtls_uri_regex"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "apisix_ssl_certificate.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+			// Update and Read testing
+			{
+				Config: config(fmt.Sprintf(`{
+		ca                  = %q
+		depth               = 2
+		skip_mtls_uri_regex = ["/healthz$"]
+	}`, ca)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "client.depth", "2"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "client.skip_mtls_uri_regex.#", "1"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "client.skip_mtls_uri_regex.0", "/healthz$"),
+				),
+			},
+			// Remove the client verification
+			{
+				Config: config("null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("apisix_ssl_certificate.test", "client.ca"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSSLResourceMetadata(t *testing.T) {
+	rsaCertificate, rsaPrivateKey := testCertificate(t, testRSAKey(t), "metadata.example.com")
+	ecdsaCertificate, ecdsaPrivateKey := testCertificate(t, testECDSAKey(t), "metadata.example.com")
+
+	block, _ := pem.Decode([]byte(ecdsaCertificate))
+	fingerprint := sha256.Sum256(block.Bytes)
+
+	config := func(certificate, privateKey string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "apisix_ssl_certificate" "test" {
+	certificate = %q
+	private_key = %q
+}
+`, certificate, privateKey)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(rsaCertificate, rsaPrivateKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "subject", "CN=metadata.example.com"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "issuer", "CN=metadata.example.com"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "key_algorithm", "RSA"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "key_size", "2048"),
+					resource.TestMatchResourceAttr("apisix_ssl_certificate.test", "not_before", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+					resource.TestMatchResourceAttr("apisix_ssl_certificate.test", "not_after", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+					resource.TestMatchResourceAttr("apisix_ssl_certificate.test", "serial_number", regexp.MustCompile(`^\d+$`)),
+					resource.TestMatchResourceAttr("apisix_ssl_certificate.test", "fingerprint_sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "apisix_ssl_certificate.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+			// Update and Read testing
+			{
+				Config: config(ecdsaCertificate, ecdsaPrivateKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "key_algorithm", "ECDSA"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "key_size", "256"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "fingerprint_sha256", hex.EncodeToString(fingerprint[:])),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSSLResourceValidation(t *testing.T) {
+	caKey := testECDSAKey(t)
+	caTemplate := testCertificateTemplate("Example CA")
+	caTemplate.DNSNames = nil
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign
+	caCertificate, _, ca := testSignCertificate(t, caTemplate, caKey, caTemplate, caKey)
+
+	leafKey := testECDSAKey(t)
+	leafCertificate, leafPrivateKey, _ := testSignCertificate(t, testCertificateTemplate("*.chain.example.com"), leafKey, ca, caKey)
+	_, otherPrivateKey := testCertificate(t, testECDSAKey(t), "other.example.com")
+
+	expiredTemplate := testCertificateTemplate("expired.example.com")
+	expiredTemplate.NotBefore = time.Now().Add(-48 * time.Hour)
+	expiredTemplate.NotAfter = time.Now().Add(-24 * time.Hour)
+	expiredKey := testECDSAKey(t)
+	expiredCertificate, expiredPrivateKey, _ := testSignCertificate(t, expiredTemplate, expiredKey, expiredTemplate, expiredKey)
+
+	config := func(certificate, privateKey, snis string, minDaysRemaining int) string {
+		return providerConfig + fmt.Sprintf(`
+resource "apisix_ssl_certificate" "test" {
+	certificate        = %q
+	private_key        = %q
+	snis               = %s
+	min_days_remaining = %d
+}
+`, certificate, privateKey, snis, minDaysRemaining)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The private key doesn't match the certificate
+			{
+				Config:      config(leafCertificate+caCertificate, otherPrivateKey, "null", 30),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Private Key`),
+			},
+			// The issuer precedes the leaf certificate
+			{
+				Config:      config(caCertificate+leafCertificate, leafPrivateKey, "null", 30),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Certificate Chain`),
+			},
+			// The SNI isn't covered by the certificate
+			{
+				Config:      config(leafCertificate+caCertificate, leafPrivateKey, `["api.other.example.com"]`, 30),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid SNIs`),
+			},
+			// The certificate is expired
+			{
+				Config:      config(expiredCertificate, expiredPrivateKey, "null", 30),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Expired Certificate`),
+			},
+			// The certificate expires in less than the minimum days
+			{
+				Config:      config(leafCertificate+caCertificate, leafPrivateKey, "null", 120),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Certificate Expires Soon`),
+			},
+			// Create and Read testing
+			{
+				Config: config(leafCertificate+caCertificate, leafPrivateKey, `["api.chain.example.com", "*.chain.example.com"]`, 30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "min_days_remaining", "30"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "snis.#", "2"),
+					resource.TestCheckResourceAttr("apisix_ssl_certificate.test", "issuer", "CN=Example CA"),
+				),
+			},
+			// ImportState testing, the expiry guard is configured in Terraform only
+			{
+				ResourceName:            "apisix_ssl_certificate.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key", "min_days_remaining"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testRSAKey(t *testing.T) crypto.Signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func testECDSAKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+// testCertificate returns the PEM encoded self-signed certificate of the DNS names and its private key.
+func testCertificate(t *testing.T, key crypto.Signer, dnsNames ...string) (string, string) {
+	template := testCertificateTemplate(dnsNames...)
+	certificate, privateKey, _ := testSignCertificate(t, template, key, template, key)
+
+	return certificate, privateKey
+}
+
+// testCertificateTemplate returns the template of a certificate of the DNS names valid for 90 days.
+func testCertificateTemplate(dnsNames ...string) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+}
+
+// testSignCertificate returns the PEM encoded certificate of the template issued by the parent certificate,
+// its private key and the parsed certificate.
+func testSignCertificate(t *testing.T, template *x509.Certificate, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (string, string, *x509.Certificate) {
+	data, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: data})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey})),
+		certificate
+}
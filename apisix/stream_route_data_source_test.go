@@ -0,0 +1,47 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestStreamRouteDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_upstream" "test" {
+	type = "roundrobin"
+	nodes = [
+		{
+			host   = "127.0.0.1"
+			port   = 8080
+			weight = 1
+		}
+	]
+}
+
+resource "apisix_stream_route" "test" {
+	upstream_id = apisix_upstream.test.id
+	server_port = 8080
+	sni         = "example.com"
+}
+
+data "apisix_stream_route" "test" {
+	id = apisix_stream_route.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_stream_route.test", "id", "apisix_stream_route.test", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_stream_route.test", "upstream_id", "apisix_stream_route.test", "upstream_id"),
+					resource.TestCheckResourceAttrPair("data.apisix_stream_route.test", "server_port", "apisix_stream_route.test", "server_port"),
+					resource.TestCheckResourceAttrPair("data.apisix_stream_route.test", "sni", "apisix_stream_route.test", "sni"),
+				),
+			},
+		},
+	})
+}
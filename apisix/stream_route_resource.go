@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -15,9 +15,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &streamRouteResource{}
-	_ resource.ResourceWithConfigure   = &streamRouteResource{}
-	_ resource.ResourceWithImportState = &streamRouteResource{}
+	_ resource.Resource                     = &streamRouteResource{}
+	_ resource.ResourceWithConfigure        = &streamRouteResource{}
+	_ resource.ResourceWithImportState      = &streamRouteResource{}
+	_ resource.ResourceWithConfigValidators = &streamRouteResource{}
+	_ resource.ResourceWithModifyPlan       = &streamRouteResource{}
 )
 
 // NewStreamRouteResource is a helper function to simplify the provider implementation.
@@ -27,7 +29,7 @@ func NewStreamRouteResource() resource.Resource {
 
 // streamRouteResource is the resource implementation.
 type streamRouteResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -40,18 +42,38 @@ func (r *streamRouteResource) Schema(_ context.Context, _ resource.SchemaRequest
 	resp.Schema = model.StreamRouteSchema
 }
 
+// Validate Config
+func (r *streamRouteResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("upstream_id"),
+			path.MatchRoot("upstream"),
+			path.MatchRoot("service_id"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("upstream"),
+			path.MatchRoot("upstream_id"),
+		),
+	}
+}
+
+// ModifyPlan validates the plugins against the plugin schemas of APISIX.
+func (r *streamRouteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(validatePlugins(ctx, r.client, req.Plan, admin.SubsystemStream, admin.SchemaTypeRoute)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *streamRouteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -70,11 +92,43 @@ func (r *streamRouteResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	newStreamRouteRequest := model.StreamRouteFromTerraformToApi(ctx, &plan)
+	newStreamRouteRequest, diags := model.StreamRouteFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Create new stream route
-	newStreamRouteReponse, err := r.client.CreateStreamRoute(newStreamRouteRequest)
+	// Fail instead of overwriting the object with the configured identifier created outside of Terraform
+	if !plan.ID.IsUnknown() {
+		resp.Diagnostics.Append(validateCreate(client, "Stream Route", "apisix_stream_route", plan.ID.ValueString(), func(client *admin.Client) error {
+			_, err := client.GetStreamRoute(plan.ID.ValueString())
+			return err
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create new stream route, using the configured identifier or the one generated by APISIX
+	var newStreamRouteReponse *admin.StreamRoute
+	var err error
+	if plan.ID.IsUnknown() {
+		newStreamRouteReponse, err = client.CreateStreamRoute(newStreamRouteRequest)
+	} else {
+		newStreamRouteReponse, err = client.UpdateStreamRoute(plan.ID.ValueString(), newStreamRouteRequest)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Stream Route",
@@ -84,7 +138,17 @@ func (r *streamRouteResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	newState := model.StreamRouteFromApiToTerraform(ctx, newStreamRouteReponse)
+	newState, diags := model.StreamRouteFromApiToTerraform(ctx, newStreamRouteReponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemStream, admin.SchemaTypeRoute)
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -105,9 +169,27 @@ func (r *streamRouteResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed stream route from the APISIX
-	streamRouteStateResponse, err := r.client.GetStreamRoute(state.ID.ValueString())
+	streamRouteStateResponse, err := client.GetStreamRoute(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The stream route is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Stream Route",
 			"Could not read APISIX Stream Route by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -116,7 +198,17 @@ func (r *streamRouteResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Overwrite with refreshed state
-	newState := model.StreamRouteFromApiToTerraform(ctx, streamRouteStateResponse)
+	newState, diags := model.StreamRouteFromApiToTerraform(ctx, streamRouteStateResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemStream, admin.SchemaTypeRoute)
+
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -137,11 +229,26 @@ func (r *streamRouteResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
-	updateStreamRouteRequest := model.StreamRouteFromTerraformToApi(ctx, &plan)
+	updateStreamRouteRequest, diags := model.StreamRouteFromTerraformToApi(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update existing stream route
-	_, err := r.client.UpdateStreamRoute(plan.ID.ValueString(), updateStreamRouteRequest)
+	_, err := client.UpdateStreamRoute(plan.ID.ValueString(), updateStreamRouteRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Stream Route",
@@ -151,7 +258,7 @@ func (r *streamRouteResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Fetch updated stream route
-	updatedStreamRoute, err := r.client.GetStreamRoute(plan.ID.ValueString())
+	updatedStreamRoute, err := client.GetStreamRoute(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Stream Route",
@@ -160,7 +267,17 @@ func (r *streamRouteResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	newState := model.StreamRouteFromApiToTerraform(ctx, updatedStreamRoute)
+	newState, diags := model.StreamRouteFromApiToTerraform(ctx, updatedStreamRoute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Register the defaults of the plugins set by APISIX, so they match the configured plugins
+	registerPluginDefaults(ctx, client, newState.Plugins, admin.SubsystemStream, admin.SchemaTypeRoute)
+
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -181,8 +298,18 @@ func (r *streamRouteResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete the Stream Route
-	err := r.client.DeleteStreamRoute(state.ID.ValueString())
+	err := client.DeleteStreamRoute(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Stream Route",
@@ -195,6 +322,15 @@ func (r *streamRouteResource) Delete(ctx context.Context, req resource.DeleteReq
 // Import resource into state
 func (r *streamRouteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the stream route importing")
+	// Validate the import ID and check the stream route exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Stream Route", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetStreamRoute(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -1,6 +1,7 @@
 package apisix
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -76,3 +77,112 @@ resource "apisix_stream_route" "test" {
 		},
 	})
 }
+
+func TestStreamRouteResourcePluginsAndUpstream(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing upstream
+			{
+				Config: providerConfig + `
+resource "apisix_stream_route" "test" {
+	server_port = 9100
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+			// Inline upstream without nodes and service_name
+			{
+				Config: providerConfig + `
+resource "apisix_stream_route" "test" {
+	server_port = 9100
+	upstream = {
+		type = "roundrobin"
+	}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Exactly one of upstream.nodes and\s+upstream.service_name`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_stream_route" "test" {
+	server_port = 9100
+	upstream = {
+		nodes = [
+			{
+				host = "127.0.0.1"
+				port = 6379
+			},
+		]
+	}
+	plugins = jsonencode({
+		"ip-restriction" = {
+			whitelist = ["127.0.0.0/24"]
+		}
+	})
+	protocol = {
+		name = "redis"
+		conf = jsonencode({
+			faults = [
+				{
+					commands = ["get"]
+					key      = "bogus_key"
+					delay    = 0.1
+				}
+			]
+		})
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_stream_route.test", "id"),
+					resource.TestCheckResourceAttr("apisix_stream_route.test", "upstream.type", "roundrobin"),
+					resource.TestCheckResourceAttr("apisix_stream_route.test", "protocol.name", "redis"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_stream_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_service" "test" {
+	upstream = {
+		nodes = [
+			{
+				host = "127.0.0.1"
+				port = 6379
+			},
+		]
+	}
+}
+
+resource "apisix_stream_route" "test" {
+	server_port = 9100
+	service_id  = apisix_service.test.id
+	plugins = jsonencode({
+		"ip-restriction" = {
+			blacklist = ["10.0.0.0/8"]
+		}
+	})
+	protocol = {
+		name = "redis"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("apisix_stream_route.test", "service_id", "apisix_service.test", "id"),
+					resource.TestCheckNoResourceAttr("apisix_stream_route.test", "upstream"),
+					resource.TestCheckNoResourceAttr("apisix_stream_route.test", "protocol.conf"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
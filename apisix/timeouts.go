@@ -0,0 +1,11 @@
+package apisix
+
+import "time"
+
+// Default timeouts of the resource operations.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
@@ -0,0 +1,46 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUpstreamDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_upstream" "test" {
+	name = "Example"
+	type = "roundrobin"
+	nodes = [
+		{
+			host   = "127.0.0.1"
+			port   = 1980
+			weight = 1
+		}
+	]
+}
+
+data "apisix_upstream" "test" {
+	id = apisix_upstream.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source returns the attributes of the managed object
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "id", "apisix_upstream.test", "id"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "name", "apisix_upstream.test", "name"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "type", "apisix_upstream.test", "type"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "nodes.#", "apisix_upstream.test", "nodes.#"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "nodes.0.host", "apisix_upstream.test", "nodes.0.host"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "nodes.0.port", "apisix_upstream.test", "nodes.0.port"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "pass_host", "apisix_upstream.test", "pass_host"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstream.test", "scheme", "apisix_upstream.test", "scheme"),
+				),
+			},
+		},
+	})
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
+	"terraform-provider-apisix/apisix/admin"
 	"terraform-provider-apisix/apisix/model"
 
+	"github.com/holubovskyi/apisix-client-go"
+
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	//	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -30,7 +31,7 @@ func NewUpstreamResource() resource.Resource {
 
 // upstreamResource is the resource implementation.
 type upstreamResource struct {
-	client *api_client.ApiClient
+	client *admin.Client
 }
 
 // Metadata returns the resource type name.
@@ -63,12 +64,12 @@ func (r *upstreamResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*admin.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -88,6 +89,16 @@ func (r *upstreamResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
 	newUpstreamRequest, labelsDiag := model.UpstreamFromTerraformToAPI(ctx, &plan)
 
@@ -96,8 +107,25 @@ func (r *upstreamResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Create new upstream
-	newUpstreamResponse, err := r.client.CreateUpstream(newUpstreamRequest)
+	// Fail instead of overwriting the object with the configured identifier created outside of Terraform
+	if !plan.ID.IsUnknown() {
+		resp.Diagnostics.Append(validateCreate(client, "Upstream", "apisix_upstream", plan.ID.ValueString(), func(client *admin.Client) error {
+			_, err := client.GetUpstream(plan.ID.ValueString())
+			return err
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create new upstream, using the configured identifier or the one generated by APISIX
+	var newUpstreamResponse *api_client.Upstream
+	var err error
+	if plan.ID.IsUnknown() {
+		newUpstreamResponse, err = client.CreateUpstream(newUpstreamRequest)
+	} else {
+		newUpstreamResponse, err = client.UpdateUpstream(plan.ID.ValueString(), newUpstreamRequest)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Upstream",
@@ -114,6 +142,9 @@ func (r *upstreamResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -133,9 +164,27 @@ func (r *upstreamResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Get refreshed upstream from the APISIX
-	upsreamResponse, err := r.client.GetUpstream(state.ID.ValueString())
+	upsreamResponse, err := client.GetUpstream(state.ID.ValueString())
 	if err != nil {
+		if admin.IsNotFound(err) {
+			tflog.Warn(ctx, "The upstream is not found in APISIX, removing it from the state", map[string]any{
+				"ID": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Upstream",
 			"Could not read APISIX Upstream by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -151,6 +200,9 @@ func (r *upstreamResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = state.Timeouts
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -171,6 +223,16 @@ func (r *upstreamResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Generate API request body from plan
 	updateUpstreamRequest, labelsDiag := model.UpstreamFromTerraformToAPI(ctx, &plan)
 
@@ -180,7 +242,7 @@ func (r *upstreamResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Update existing upstream
-	_, err := r.client.UpdateUpstream(plan.ID.ValueString(), updateUpstreamRequest)
+	_, err := client.UpdateUpstream(plan.ID.ValueString(), updateUpstreamRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating APISIX Upstream",
@@ -190,7 +252,7 @@ func (r *upstreamResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Fetch updated upstream from APISIX
-	updatedUpstream, err := r.client.GetUpstream(plan.ID.ValueString())
+	updatedUpstream, err := client.GetUpstream(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Upstream",
@@ -205,6 +267,9 @@ func (r *upstreamResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// Keep the configured timeouts
+	newState.Timeouts = plan.Timeouts
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -224,8 +289,18 @@ func (r *upstreamResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	client := r.client.WithContext(ctx)
+
 	// Delete existing certificate
-	err := r.client.DeleteUpstream(state.ID.ValueString())
+	err := client.DeleteUpstream(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Upstream",
@@ -238,6 +313,15 @@ func (r *upstreamResource) Delete(ctx context.Context, req resource.DeleteReques
 // Import resource into state
 func (r *upstreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the upstream importing")
+	// Validate the import ID and check the upstream exists
+	resp.Diagnostics.Append(validateImport(ctx, r.client, "Upstream", req.ID, model.ValidateObjectID, func(client *admin.Client) error {
+		_, err := client.GetUpstream(req.ID)
+		return err
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -1,9 +1,14 @@
 package apisix
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 func TestUpstreamResource(t *testing.T) {
@@ -140,3 +145,189 @@ resource "apisix_upstream" "test" {
 		},
 	})
 }
+
+func TestUpstreamResourceTimeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Exceeded create timeout
+			{
+				Config: providerConfig + `
+resource "apisix_upstream" "test" {
+	type = "roundrobin"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1980
+		}
+	]
+
+	timeouts {
+		create = "1ns"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`context deadline exceeded`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_upstream" "test" {
+	type = "roundrobin"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1980
+		}
+	]
+
+	timeouts {
+		create = "1m"
+		read   = "30s"
+		update = "1m"
+		delete = "30s"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("apisix_upstream.test", "id"),
+					resource.TestCheckResourceAttr("apisix_upstream.test", "timeouts.create", "1m"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "apisix_upstream.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_upstream" "test" {
+	type = "roundrobin"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1980
+		}
+	]
+
+	timeouts {
+		update = "2m"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_upstream.test", "timeouts.update", "2m"),
+					resource.TestCheckNoResourceAttr("apisix_upstream.test", "timeouts.create"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestUpstreamResourceCustomID(t *testing.T) {
+	config := func(id string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "apisix_upstream" "test" {
+	id   = %q
+	type = "roundrobin"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1980
+		}
+	]
+}
+`, id)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid identifier
+			{
+				Config:      config("invalid/id"),
+				ExpectError: regexp.MustCompile(`must contain only alphanumeric characters`),
+			},
+			// Create with the configured identifier
+			{
+				Config: config("custom-upstream"),
+				Check:  resource.TestCheckResourceAttr("apisix_upstream.test", "id", "custom-upstream"),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "apisix_upstream.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Changing the identifier replaces the upstream
+			{
+				Config: config("custom-upstream.v2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("apisix_upstream.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("apisix_upstream.test", "id", "custom-upstream.v2"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestUpstreamResourceExistingID(t *testing.T) {
+	upstreamType := "roundrobin"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The upstream created outside of Terraform isn't overwritten
+			{
+				PreConfig: func() {
+					client := testAccAPIClient(t)
+					_, err := client.UpdateUpstream("existing-upstream", api_client.Upstream{
+						Type: &upstreamType,
+						Nodes: &[]api_client.UpstreamNodeType{
+							{Host: "127.0.0.1", Port: 1980, Weight: 1},
+						},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					t.Cleanup(func() {
+						_ = client.DeleteUpstream("existing-upstream")
+					})
+				},
+				Config: providerConfig + `
+resource "apisix_upstream" "test" {
+	id   = "existing-upstream"
+	type = "chash"
+	key  = "remote_addr"
+	nodes = [
+		{
+			host = "127.0.0.1"
+			port = 1981
+		}
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`APISIX Upstream Already Exists`),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			upstream, err := testAccAPIClient(t).GetUpstream("existing-upstream")
+			if err != nil {
+				return err
+			}
+			if *upstream.Type != upstreamType {
+				return fmt.Errorf("expected the existing upstream to be kept, got the %s type", *upstream.Type)
+			}
+
+			return nil
+		},
+	})
+}
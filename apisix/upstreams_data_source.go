@@ -0,0 +1,116 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &upstreamsDataSource{}
+	_ datasource.DataSourceWithConfigure = &upstreamsDataSource{}
+)
+
+// NewUpstreamsDataSource is a helper function to simplify the provider implementation.
+func NewUpstreamsDataSource() datasource.DataSource {
+	return &upstreamsDataSource{}
+}
+
+// upstreamsDataSource is the data source implementation.
+type upstreamsDataSource struct {
+	client *admin.Client
+}
+
+// Metadata returns the data source type name.
+func (d *upstreamsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_upstreams"
+}
+
+// Schema defines the schema for the data source.
+func (d *upstreamsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dataSourceSchema, err := model.UpstreamsDataSourceSchema()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Data Source Schema",
+			"Could not build the upstreams data source schema, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Schema = dataSourceSchema
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *upstreamsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*admin.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *admin.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *upstreamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Start of the upstreams data source read")
+	// Get the upstreams filters
+	var state model.UpstreamsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get all upstreams from the APISIX
+	upstreamsResponse, err := d.client.ListUpstreams()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APISIX Upstreams",
+			"Could not list APISIX Upstreams: "+err.Error(),
+		)
+		return
+	}
+
+	upstreams, diags := model.UpstreamsFromApiToTerraform(ctx, upstreamsResponse, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upstreamsType, diags := resp.State.Schema.TypeAtPath(ctx, path.Root("upstreams"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue("placeholder")
+	state.Upstreams, diags = model.DataSourceListValue(ctx, model.UpstreamSchema, upstreamsType, upstreams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,65 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUpstreamsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_upstream" "payments" {
+	name = "payments-upstream"
+	labels = {
+		team = "payments-ds-test"
+	}
+	nodes = [
+		{
+			host   = "127.0.0.1"
+			port   = 1980
+			weight = 1
+		}
+	]
+}
+
+resource "apisix_upstream" "orders" {
+	name = "orders-upstream"
+	labels = {
+		team = "orders-ds-test"
+	}
+	nodes = [
+		{
+			host   = "127.0.0.1"
+			port   = 1981
+			weight = 1
+		}
+	]
+}
+
+data "apisix_upstreams" "by_labels" {
+	labels = {
+		team = "payments-ds-test"
+	}
+	depends_on = [apisix_upstream.payments, apisix_upstream.orders]
+}
+
+data "apisix_upstreams" "by_name_prefix" {
+	name_prefix = "orders-"
+	depends_on  = [apisix_upstream.payments, apisix_upstream.orders]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.apisix_upstreams.by_labels", "upstreams.#", "1"),
+					resource.TestCheckResourceAttrPair("data.apisix_upstreams.by_labels", "upstreams.0.id", "apisix_upstream.payments", "id"),
+					resource.TestCheckResourceAttr("data.apisix_upstreams.by_name_prefix", "upstreams.#", "1"),
+					resource.TestCheckResourceAttr("data.apisix_upstreams.by_name_prefix", "upstreams.0.nodes.0.port", "1981"),
+				),
+			},
+		},
+	})
+}
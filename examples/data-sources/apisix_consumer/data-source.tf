@@ -0,0 +1,3 @@
+data "apisix_consumer" "example" {
+  username = "example"
+}
@@ -0,0 +1,3 @@
+data "apisix_consumer_group" "example" {
+  id = "001"
+}
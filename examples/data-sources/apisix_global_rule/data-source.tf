@@ -0,0 +1,3 @@
+data "apisix_global_rule" "example" {
+  id = "123"
+}
@@ -0,0 +1,3 @@
+data "apisix_plugin_config" "example" {
+  id = "001"
+}
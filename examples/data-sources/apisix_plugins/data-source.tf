@@ -0,0 +1,23 @@
+data "apisix_plugins" "enabled" {}
+
+# Enable the rate limiting only on the gateways which have the plugin enabled
+resource "apisix_route" "example" {
+  uri         = "/api/*"
+  upstream_id = "1"
+  plugins = jsonencode(
+    contains(data.apisix_plugins.enabled.names, "limit-count") ? {
+      limit-count = {
+        count       = 100
+        time_window = 60
+      }
+    } : {}
+  )
+}
+
+# Fail fast when the required plugin isn't enabled
+check "required_plugins" {
+  assert {
+    condition     = contains(data.apisix_plugins.enabled.names, "key-auth")
+    error_message = "The key-auth plugin must be enabled in the APISIX config.yaml."
+  }
+}
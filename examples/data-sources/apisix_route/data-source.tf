@@ -0,0 +1,8 @@
+# Look up a route managed outside of this configuration
+data "apisix_route" "example" {
+  id = "123"
+}
+
+output "route_uri" {
+  value = data.apisix_route.example.uri
+}
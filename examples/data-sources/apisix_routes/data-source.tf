@@ -0,0 +1,16 @@
+# Find every route owned by the payments team
+data "apisix_routes" "payments" {
+  labels = {
+    team = "payments"
+  }
+}
+
+# Find routes serving the given host under the "public-" name prefix
+data "apisix_routes" "public" {
+  name_prefix = "public-"
+  host        = "api.example.com"
+}
+
+output "payments_route_ids" {
+  value = data.apisix_routes.payments.routes[*].id
+}
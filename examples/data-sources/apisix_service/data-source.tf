@@ -0,0 +1,3 @@
+data "apisix_service" "example" {
+  id = "123"
+}
@@ -0,0 +1,9 @@
+data "apisix_services" "payments" {
+  labels = {
+    team = "payments"
+  }
+}
+
+output "payments_service_names" {
+  value = data.apisix_services.payments.services[*].name
+}
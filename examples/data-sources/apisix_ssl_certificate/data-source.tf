@@ -0,0 +1,7 @@
+data "apisix_ssl_certificate" "example" {
+  id = "123"
+}
+
+output "certificate_snis" {
+  value = data.apisix_ssl_certificate.example.snis
+}
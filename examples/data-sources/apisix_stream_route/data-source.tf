@@ -0,0 +1,3 @@
+data "apisix_stream_route" "example" {
+  id = "123"
+}
@@ -0,0 +1,9 @@
+# Look up a shared upstream and reference it from a route
+data "apisix_upstream" "shared" {
+  id = "123"
+}
+
+resource "apisix_route" "example" {
+  uri         = "/api/v1"
+  upstream_id = data.apisix_upstream.shared.id
+}
@@ -0,0 +1,10 @@
+data "apisix_upstreams" "payments" {
+  labels = {
+    team = "payments"
+  }
+  name_prefix = "payments-"
+}
+
+output "payments_upstream_ids" {
+  value = data.apisix_upstreams.payments.upstreams[*].id
+}
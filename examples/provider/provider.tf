@@ -2,4 +2,36 @@
 provider "apisix" {
   endpoint = "http://127.0.0.1:9180"
   api_key  = "edd1c9f034335f136f87ad84b625c8f1"
+
+  # Retry the requests while APISIX or etcd are unavailable
+  request_timeout = "30s"
+  max_retries     = 5
+  retry_backoff   = "2s"
+}
+
+# Admin API behind the mutual TLS
+provider "apisix" {
+  alias              = "mtls"
+  endpoint           = "https://apisix-admin.internal:9180"
+  api_key            = "edd1c9f034335f136f87ad84b625c8f1"
+  ca_certificate     = file("${path.module}/ca.pem")
+  client_certificate = file("${path.module}/client.pem")
+  client_key         = file("${path.module}/client-key.pem")
+}
+
+# Several Admin API nodes with failover
+provider "apisix" {
+  alias = "cluster"
+  endpoints = [
+    "http://apisix-admin-1.internal:9180",
+    "http://apisix-admin-2.internal:9180",
+  ]
+  api_key = "edd1c9f034335f136f87ad84b625c8f1"
+}
+
+# APISIX in the standalone mode, reading the declarative configuration file
+provider "apisix" {
+  alias           = "standalone"
+  mode            = "standalone"
+  standalone_file = "/usr/local/apisix/conf/apisix.yaml"
 }
@@ -13,3 +13,12 @@ resource "apisix_consumer" "example" {
     }
   )
 }
+
+resource "apisix_consumer" "typed_plugins" {
+  username = "typed"
+  typed_plugins = {
+    key_auth = {
+      key = "changeme"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Plugin Metadata can be imported by specifying the plugin name.
+terraform import apisix_plugin_metadata.example http-logger
\ No newline at end of file
@@ -0,0 +1,12 @@
+resource "apisix_plugin_metadata" "example" {
+  plugin_name = "http-logger"
+  metadata = jsonencode(
+    {
+      log_format = {
+        host         = "$host"
+        "@timestamp" = "$time_iso8601"
+        client_ip    = "$remote_addr"
+      }
+    }
+  )
+}
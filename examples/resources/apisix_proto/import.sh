@@ -0,0 +1,2 @@
+# Proto can be imported by specifying the identifier.
+terraform import apisix_proto.example 1
\ No newline at end of file
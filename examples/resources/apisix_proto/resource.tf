@@ -0,0 +1,31 @@
+resource "apisix_proto" "example" {
+  desc         = "Example of the proto resource usage"
+  content_file = "${path.module}/helloworld.proto"
+  labels = {
+    version = "v1"
+  }
+}
+
+# The proto is referenced by its identifier in the grpc-transcode plugin configuration
+resource "apisix_route" "example" {
+  uri     = "/grpctest"
+  methods = ["GET"]
+  plugins = jsonencode(
+    {
+      grpc-transcode = {
+        proto_id = apisix_proto.example.id
+        service  = "helloworld.Greeter"
+        method   = "SayHello"
+      }
+    }
+  )
+  upstream = {
+    scheme = "grpc"
+    nodes = [
+      {
+        host = "127.0.0.1"
+        port = 50051
+      },
+    ]
+  }
+}
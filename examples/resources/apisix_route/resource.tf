@@ -25,3 +25,47 @@ resource "apisix_route" "example" {
     "version" = "v1"
   }
 }
+
+resource "apisix_route" "inline_upstream" {
+  name = "Example with inline upstream"
+  uri  = "/api/v2/*"
+  upstream = {
+    type = "roundrobin"
+    nodes = [
+      {
+        host   = "127.0.0.1"
+        port   = 1980
+        weight = 1
+      },
+    ]
+  }
+}
+
+# Route with the stable identifier referenced by the other tooling
+resource "apisix_route" "stable" {
+  id          = "orders-api"
+  uri         = "/orders/*"
+  upstream_id = "1"
+}
+
+# Route with the typed plugins, merged with the plugins JSON
+resource "apisix_route" "typed_plugins" {
+  name        = "Example with typed plugins"
+  uri         = "/api/v3/*"
+  upstream_id = "1"
+  plugins = jsonencode(
+    {
+      prometheus = {}
+    }
+  )
+  typed_plugins = {
+    limit_count = {
+      count         = 100
+      time_window   = 60
+      rejected_code = 429
+    }
+    cors = {
+      allow_origins = "https://example.com"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Secret can be imported by specifying the secret manager and the identifier in the form of manager/id.
+terraform import apisix_secret.vault vault/example
\ No newline at end of file
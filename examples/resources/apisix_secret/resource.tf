@@ -0,0 +1,29 @@
+resource "apisix_secret" "vault" {
+  secret_id = "example"
+  vault = {
+    uri    = "http://127.0.0.1:8200"
+    prefix = "kv/apisix"
+    token  = var.vault_token
+  }
+}
+
+resource "apisix_secret" "aws" {
+  secret_id = "example"
+  aws = {
+    access_key_id     = var.aws_access_key_id
+    secret_access_key = var.aws_secret_access_key
+    region            = "eu-central-1"
+  }
+}
+
+# The secrets are referenced in the plugins configuration with the $secret:// URIs
+resource "apisix_consumer" "example" {
+  username = "example"
+  plugins = jsonencode(
+    {
+      key-auth = {
+        key = "$secret://vault/example/example/key"
+      }
+    }
+  )
+}
@@ -2,7 +2,35 @@ resource "apisix_ssl_certificate" "example" {
   certificate = file("example.crt")
   private_key = file("example.key")
   type        = "server"
+
+  # Fail the plan unless the certificate is valid for 30 more days
+  min_days_remaining = 30
+
   labels = {
     "version" = "v1"
   }
-}
\ No newline at end of file
+}
+
+# Serve both RSA and ECDSA certificates for the same domains
+resource "apisix_ssl_certificate" "dual" {
+  certificate             = file("example-rsa.crt")
+  private_key             = file("example-rsa.key")
+  additional_certificates = [file("example-ecdsa.crt")]
+  additional_private_keys = [file("example-ecdsa.key")]
+}
+
+# Require the partners to present a client certificate signed by the CA
+resource "apisix_ssl_certificate" "partners" {
+  certificate = file("partners.crt")
+  private_key = file("partners.key")
+  client = {
+    ca                  = file("partners-ca.crt")
+    depth               = 2
+    skip_mtls_uri_regex = ["^/healthz$"]
+  }
+}
+
+# Alert on the certificates expiring within 30 days
+output "example_certificate_expires_soon" {
+  value = timecmp(apisix_ssl_certificate.example.not_after, timeadd(timestamp(), "720h")) < 0
+}
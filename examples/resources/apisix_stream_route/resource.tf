@@ -15,4 +15,23 @@ resource "apisix_stream_route" "example" {
   server_addr = "127.0.0.1"
   server_port = 8080
   sni         = "example.com"
-}
\ No newline at end of file
+}
+resource "apisix_stream_route" "redis" {
+  server_port = 9100
+  upstream = {
+    nodes = [
+      {
+        host = "127.0.0.1"
+        port = 6379
+      },
+    ]
+  }
+  plugins = jsonencode({
+    "ip-restriction" = {
+      whitelist = ["10.0.0.0/8"]
+    }
+  })
+  protocol = {
+    name = "redis"
+  }
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/holubovskyi/apisix-client-go"
+
+	"terraform-provider-apisix/apisix/admin"
+	"terraform-provider-apisix/apisix/generate"
+)
+
+// runGenerate writes the Terraform configuration of the objects in the existing APISIX instance.
+//
+//	terraform-provider-apisix generate -endpoint http://127.0.0.1:9180 -api-key ... -output apisix.tf
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	endpoint := flags.String("endpoint", os.Getenv("APISIX_ENDPOINT"), "endpoint of the APISIX Admin API, defaults to APISIX_ENDPOINT")
+	apiKey := flags.String("api-key", os.Getenv("APISIX_API_KEY"), "key of the APISIX Admin API, defaults to APISIX_API_KEY")
+	output := flags.String("output", "", "file to write the configuration to, defaults to the standard output")
+	timeout := flags.Duration("timeout", time.Minute, "timeout of the generation")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *endpoint == "" {
+		return errors.New("missing APISIX API endpoint, set the -endpoint flag or the APISIX_ENDPOINT environment variable")
+	}
+
+	apiClient, err := api_client.NewClient(endpoint, apiKey)
+	if err != nil {
+		return err
+	}
+	apiClient.HTTPClient = &http.Client{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		writer = file
+	}
+
+	return generate.Generate(ctx, admin.NewClient(apiClient).WithContext(ctx), writer)
+}
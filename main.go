@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -30,6 +31,15 @@ var (
 )
 
 func main() {
+	// Generate the configuration of the existing APISIX instance instead of serving the provider
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		err := runGenerate(os.Args[2:])
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")